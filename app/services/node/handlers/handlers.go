@@ -10,9 +10,15 @@ import (
 
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/app/services/node/handlers/debug/checkgrp"
 	v1 "github.com/Kunmeer-SyedMohamedHyder/blockchain/app/services/node/handlers/v1"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/sys/validate"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/web/auth"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/web/metrics"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/web/v1/mid"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/bus"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/state"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/nameservice"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/ratelimit"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/tracing"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/web"
 	"go.uber.org/zap"
 )
@@ -23,6 +29,12 @@ type MuxConfig struct {
 	Log      *zap.SugaredLogger
 	State    *state.State
 	NS       *nameservice.NameService
+	Bus      *bus.Bus
+	Auth     *auth.Auth
+	Tracer   *tracing.Tracer
+
+	IPLimiter      *ratelimit.Limiter
+	AccountLimiter *ratelimit.Limiter
 }
 
 // PublicMux constructs a http.Handler with all application routes defined.
@@ -31,6 +43,8 @@ func PublicMux(cfg MuxConfig) http.Handler {
 	// Construct the web.App which holds all routes as well as common Middleware.
 	app := web.NewApp(
 		cfg.Shutdown,
+		cfg.Tracer,
+		validate.Check,
 		mid.Logger(cfg.Log),
 		mid.Errors(cfg.Log),
 		mid.Metrics(),
@@ -51,6 +65,10 @@ func PublicMux(cfg MuxConfig) http.Handler {
 		Log:   cfg.Log,
 		State: cfg.State,
 		NS:    cfg.NS,
+		Bus:   cfg.Bus,
+
+		IPLimiter:      cfg.IPLimiter,
+		AccountLimiter: cfg.AccountLimiter,
 	})
 
 	return app
@@ -62,11 +80,14 @@ func PrivateMux(cfg MuxConfig) http.Handler {
 	// Construct the web.App which holds all routes as well as common Middleware.
 	app := web.NewApp(
 		cfg.Shutdown,
+		cfg.Tracer,
+		validate.Check,
 		mid.Logger(cfg.Log),
 		mid.Errors(cfg.Log),
 		mid.Metrics(),
 		mid.Cors("*"),
 		mid.Panics(),
+		mid.Authenticate(cfg.Auth),
 	)
 
 	// Accept CORS 'OPTIONS' preflight requests if config has been provided.
@@ -118,5 +139,9 @@ func DebugMux(build string, log *zap.SugaredLogger) http.Handler {
 	mux.HandleFunc("/debug/readiness", cgh.Readiness)
 	mux.HandleFunc("/debug/liveness", cgh.Liveness)
 
+	// Register the metrics in the Prometheus format next to the expvar
+	// metrics under /debug/vars.
+	mux.Handle("/metrics", metrics.Handler())
+
 	return mux
 }
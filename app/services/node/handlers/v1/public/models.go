@@ -1,6 +1,21 @@
 package public
 
-import "github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+import (
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/nameservice"
+)
+
+type account struct {
+	Account database.AccountID `json:"account"`
+	Name    string             `json:"name"`
+	Balance uint64             `json:"balance"`
+	Nonce   uint64             `json:"nonce"`
+}
+
+type name struct {
+	Name    string             `json:"name"`
+	Account database.AccountID `json:"account"`
+}
 
 type tx struct {
 	FromAccount database.AccountID `json:"from"`
@@ -19,3 +34,68 @@ type tx struct {
 	Proof       []string           `json:"proof"`
 	ProofOrder  []int64            `json:"proof_order"`
 }
+
+// newTx converts a block transaction into the tx model, resolving the
+// account names with the name service.
+func newTx(ns *nameservice.NameService, tran database.BlockTx) tx {
+	return tx{
+		FromAccount: tran.FromID,
+		FromName:    ns.Lookup(tran.FromID),
+		To:          tran.ToID,
+		ToName:      ns.Lookup(tran.ToID),
+		ChainID:     tran.ChainID,
+		Nonce:       tran.Nonce,
+		Value:       tran.Value,
+		Tip:         tran.Tip,
+		Data:        tran.Data,
+		TimeStamp:   tran.TimeStamp,
+		GasPrice:    tran.GasPrice,
+		GasUnits:    tran.GasUnits,
+		Sig:         tran.SignatureString(),
+	}
+}
+
+// Set of transaction status values.
+const (
+	statusPending = "pending"
+	statusDropped = "dropped"
+)
+
+// rejectMalformed is the reason recorded for a submitted transaction that
+// couldn't be decoded or failed field validation.
+const rejectMalformed = "malformed"
+
+type txStatus struct {
+	Hash   string `json:"hash,omitempty"`
+	Status string `json:"status"`
+	Tx     *tx    `json:"tx,omitempty"`
+}
+
+type signedMessage struct {
+	Message   string             `json:"message" validate:"required"`
+	Signature string             `json:"signature" validate:"required"`
+	Account   database.AccountID `json:"account" validate:"omitempty,hexaccount"`
+}
+
+// verifiedMessage reports the account that signed the message. Valid is only
+// set when the request names an account to check the signer against, since
+// any well formed signature recovers to some account.
+type verifiedMessage struct {
+	Account database.AccountID `json:"account"`
+	Name    string             `json:"name"`
+	Valid   *bool              `json:"valid,omitempty"`
+}
+
+// =============================================================================
+
+// Set of event types sent on the event stream.
+const (
+	streamTxAdded  = "tx_added"
+	streamShutdown = "shutdown"
+)
+
+// streamEvent represents an event sent on the event stream.
+type streamEvent struct {
+	Type string `json:"type"`
+	Data any    `json:"data,omitempty"`
+}
@@ -0,0 +1,148 @@
+package public
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/sys/validate"
+)
+
+// Set of limits for the number of items returned in a page.
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+// page represents one page of a listing. The next cursor is provided to the
+// caller to retrieve the following page and is empty on the last page.
+type page struct {
+	Items      any    `json:"items"`
+	Total      int    `json:"total"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// pageQuery represents the paging and sorting options for a listing.
+type pageQuery struct {
+	limit  int
+	offset int
+	sort   string
+	desc   bool
+}
+
+// parsePageQuery reads the limit, cursor, sort and order query parameters.
+// The sort must be one of the specified fields, and when not provided the
+// listing keeps its natural order.
+func parsePageQuery(r *http.Request, sorts ...string) (pageQuery, error) {
+	values := r.URL.Query()
+	pq := pageQuery{
+		limit: defaultLimit,
+		sort:  values.Get("sort"),
+	}
+
+	var fe validate.FieldErrors
+
+	if limit := values.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > maxLimit {
+			fe = append(fe, validate.FieldError{Field: "limit", Error: fmt.Sprintf("must be between 1 and %d", maxLimit)})
+		}
+		pq.limit = n
+	}
+
+	if cursor := values.Get("cursor"); cursor != "" {
+		offset, err := decodeCursor(cursor)
+		if err != nil {
+			fe = append(fe, validate.FieldError{Field: "cursor", Error: "invalid cursor"})
+		}
+		pq.offset = offset
+	}
+
+	if pq.sort != "" && !contains(sorts, pq.sort) {
+		fe = append(fe, validate.FieldError{Field: "sort", Error: fmt.Sprintf("must be one of %v", sorts)})
+	}
+
+	switch values.Get("order") {
+	case "", "asc":
+	case "desc":
+		pq.desc = true
+	default:
+		fe = append(fe, validate.FieldError{Field: "order", Error: "must be asc or desc"})
+	}
+
+	if len(fe) > 0 {
+		return pageQuery{}, fe
+	}
+
+	return pq, nil
+}
+
+// bounds returns the range of items for the page out of the total number of
+// items and the cursor for the next page.
+func (pq pageQuery) bounds(total int) (start int, end int, next string) {
+	start = pq.offset
+	if start > total {
+		start = total
+	}
+
+	end = start + pq.limit
+	if end >= total {
+		return start, total, ""
+	}
+
+	return start, end, encodeCursor(end)
+}
+
+// less applies the sort order to the comparison of two items, where the
+// comparison function returns a negative number when a sorts before b.
+func (pq pageQuery) less(cmp int) bool {
+	if pq.desc {
+		return cmp > 0
+	}
+	return cmp < 0
+}
+
+// =============================================================================
+
+// encodeCursor produces an opaque cursor for the specified offset so callers
+// don't rely on its format.
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// decodeCursor returns the offset held by the cursor.
+func decodeCursor(cursor string) (int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+
+	offset, err := strconv.Atoi(string(data))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+
+	return offset, nil
+}
+
+// contains checks if the value is in the list.
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// compare returns -1, 0 or 1 as a is less than, equal to or greater than b.
+func compare(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,68 @@
+package public
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+)
+
+func TestMempoolPaging(t *testing.T) {
+
+	// Three accounts with five transactions each, where the tips and
+	// timestamps repeat so every sort has ties to break.
+	var mempool []tx
+	for a := 0; a < 3; a++ {
+		for nonce := uint64(1); nonce <= 5; nonce++ {
+			mempool = append(mempool, tx{
+				FromAccount: database.AccountID(fmt.Sprintf("0x%040d", a)),
+				Nonce:       nonce,
+				Tip:         nonce % 2,
+				TimeStamp:   1000,
+			})
+		}
+	}
+
+	for _, query := range []string{"", "sort=tip", "sort=tip&order=desc", "sort=nonce", "sort=timestamp&order=desc"} {
+		t.Run(query, func(t *testing.T) {
+			seen := make(map[string]bool)
+			var cursor string
+
+			for {
+				r := httptest.NewRequest("GET", "/?limit=4&cursor="+cursor+"&"+query, nil)
+				pq, err := parsePageQuery(r, "tip", "nonce", "timestamp")
+				if err != nil {
+					t.Fatalf("Should be able to parse the page query: %s", err)
+				}
+
+				// The mempool is a map, so every request sees it in a
+				// different order.
+				trans := make([]tx, len(mempool))
+				copy(trans, mempool)
+				rand.Shuffle(len(trans), func(i, j int) { trans[i], trans[j] = trans[j], trans[i] })
+
+				sortTxs(trans, pq)
+				start, end, next := pq.bounds(len(trans))
+
+				for _, tran := range trans[start:end] {
+					key := fmt.Sprintf("%s:%d", tran.FromAccount, tran.Nonce)
+					if seen[key] {
+						t.Fatalf("Should not see a transaction twice, got %s again", key)
+					}
+					seen[key] = true
+				}
+
+				if next == "" {
+					break
+				}
+				cursor = next
+			}
+
+			if len(seen) != len(mempool) {
+				t.Errorf("Should see every transaction once, got %d of %d", len(seen), len(mempool))
+			}
+		})
+	}
+}
@@ -3,22 +3,48 @@ package public
 
 import (
 	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/sys/validate"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/web/metrics"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/web/v1/mid"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/bus"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/signature"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/state"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/nameservice"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/ratelimit"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/web"
+	"github.com/ethereum/go-ethereum/common"
 	"go.uber.org/zap"
 )
 
+// maxTxBodySize is the largest transaction body accepted.
+const maxTxBodySize = 1 << 20
+
+// openAPI is the OpenAPI document describing the public and private routes.
+//
+//go:embed openapi.json
+var openAPI []byte
+
 // Handlers manages the set of bar ledger endpoints.
 type Handlers struct {
 	Log   *zap.SugaredLogger
 	State *state.State
 	NS    *nameservice.NameService
+	Bus   *bus.Bus
+
+	// AccountLimiter limits transaction submission by the account that
+	// signed the transaction. A nil limiter doesn't limit anything.
+	AccountLimiter *ratelimit.Limiter
 }
 
 // SubmitWalletTransaction adds new transactions to the mempool.
@@ -30,31 +56,186 @@ func (h Handlers) SubmitWalletTransaction(ctx context.Context, w http.ResponseWr
 	}
 
 	// Decode the JSON in the post call into a Signed transaction.
+	r.Body = http.MaxBytesReader(w, r.Body, maxTxBodySize)
 	var signedTx database.SignedTx
 	if err := web.Decode(r, &signedTx); err != nil {
+		metrics.AddTxRejected(ctx, rejectMalformed)
+
+		var mbe *http.MaxBytesError
+		if errors.As(err, &mbe) {
+			return validate.NewRequestError(err, http.StatusRequestEntityTooLarge)
+		}
 		return validate.NewRequestError(
 			fmt.Errorf("invalid payload to decode: %w", err), http.StatusBadRequest)
 	}
 
+	// Only a transaction with a good signature is charged to the sending
+	// account, so nobody can use up the allowance of another account. A
+	// transaction that fails is left for the state to reject.
+	if signedTx.Validate(h.State.Genesis().ChainID) == nil {
+		from := signedTx.FromID
+		if ok, wait := h.AccountLimiter.Allow(strings.ToLower(string(from))); !ok {
+			metrics.AddRateLimitedAccounts(ctx)
+			return mid.TooManyRequests(w, wait, fmt.Sprintf("too many transactions from %s", from))
+		}
+	}
+
 	h.Log.Infow("add tran", "traceid", v.TraceID, "sig:nonce", signedTx, "from", signedTx.FromID, "to", signedTx.ToID, "value", signedTx.Value, "tip", signedTx.Tip)
 
 	// Ask the state package to add this transaction to the mempool. Only the
 	// checks are the transaction signature and the recipient account format.
 	// It's up to the wallet to make sure the account has a proper balance and
 	// nonce. Fees will be taken if this transaction is mined into a block.
-	if err := h.State.UpsertWalletTransaction(signedTx); err != nil {
+	hash, err := h.State.UpsertWalletTransaction(ctx, signedTx)
+	if err != nil {
+		var re *state.RejectedError
+		if errors.As(err, &re) {
+			metrics.AddTxRejected(ctx, re.Reason)
+		}
 		return validate.NewRequestError(err, http.StatusBadRequest)
 	}
 
 	resp := struct {
 		Status string `json:"status"`
+		Hash   string `json:"hash"`
 	}{
 		Status: "transactions added to mempool",
+		Hash:   hash,
 	}
 
 	return web.Respond(ctx, w, resp, http.StatusOK)
 }
 
+// Events streams the node events to the client using server-sent events. The
+// events can be filtered by account and type with the account and type query
+// parameters, which can be repeated or hold a comma separated list.
+func (h Handlers) Events(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	v, err := web.GetValues(ctx)
+	if err != nil {
+		return web.NewShutdownError("web value missing from context")
+	}
+
+	var accounts []database.AccountID
+	for _, account := range queryList(r, "account") {
+		accountID, err := database.ToAccountID(account)
+		if err != nil {
+			return validate.NewRequestError(err, http.StatusBadRequest)
+		}
+		accounts = append(accounts, accountID)
+	}
+
+	types := make(map[string]bool)
+	for _, typ := range queryList(r, "type") {
+		types[typ] = true
+	}
+
+	// The stream stays open much longer than the server write timeout allows
+	// for a normal request, so the deadline is removed for this connection.
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		return fmt.Errorf("streaming not supported: %w", err)
+	}
+
+	// Since an event will be dropped if the stream is not ready to receive,
+	// this arbitrary buffer should give the stream enough time to not lose
+	// an event. Writing to a slow client could take long.
+	const messageBuffer = 100
+	sub := h.Bus.Subscribe("events:"+v.TraceID, messageBuffer)
+	defer h.Bus.Unsubscribe(sub)
+
+	web.SetStatusCode(ctx, http.StatusOK)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return nil
+	}
+
+	// Send a comment on a regular interval so proxies don't close the
+	// connection and a client that went away is detected.
+	ticker := time.NewTicker(15 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+
+		case msg, ok := <-sub.C():
+			if !ok {
+				return nil
+			}
+
+			if !bus.Involves(msg.Event, accounts) {
+				continue
+			}
+
+			evt, ok := h.streamEvent(msg)
+			if !ok || (len(types) > 0 && !types[evt.Type]) {
+				continue
+			}
+
+			data, err := json.Marshal(evt)
+			if err != nil {
+				h.Log.Errorw("events", "traceid", v.TraceID, "ERROR", err)
+				continue
+			}
+
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", evt.Type, data); err != nil {
+				return nil
+			}
+
+			// The node is going away, so the stream is ended rather than
+			// holding up the shutdown of the server.
+			if evt.Type == streamShutdown {
+				rc.Flush()
+				return nil
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return nil
+		}
+	}
+}
+
+// streamEvent converts the bus message into the event sent to the clients of
+// the event stream. It reports false for events that aren't streamed.
+func (h Handlers) streamEvent(msg bus.Message) (streamEvent, bool) {
+	switch ev := msg.Event.(type) {
+	case bus.TxAdmitted:
+		t := newTx(h.NS, ev.Tx)
+		return streamEvent{
+			Type: streamTxAdded,
+			Data: txStatus{Hash: ev.Hash, Status: statusPending, Tx: &t},
+		}, true
+
+	case bus.ShutdownStarted:
+		return streamEvent{Type: streamShutdown}, true
+	}
+
+	return streamEvent{}, false
+}
+
+// OpenAPI returns the OpenAPI document describing the api.
+func (h Handlers) OpenAPI(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	web.SetStatusCode(ctx, http.StatusOK)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(openAPI); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Genesis returns the genesis information.
 func (h Handlers) Genesis(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 	genesis := h.State.Genesis()
@@ -62,47 +243,94 @@ func (h Handlers) Genesis(ctx context.Context, w http.ResponseWriter, r *http.Re
 	return web.Respond(ctx, w, genesis, http.StatusOK)
 }
 
-// Mempool returns the set of uncommitted transactions.
+// Mempool returns the set of uncommitted transactions ordered by the sending
+// account and nonce. The listing is paged and can be sorted by tip, nonce or
+// timestamp instead. For a single account, the direction selects the
+// transactions the account sent or received, otherwise both are returned.
 func (h Handlers) Mempool(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 	acct := web.Param(r, "account")
 
+	direction := r.URL.Query().Get("direction")
+	switch {
+	case direction != "" && acct == "":
+		return validate.FieldErrors{{Field: "direction", Error: "only supported for an account"}}
+	case direction != "" && !contains([]string{"any", "from", "to"}, direction):
+		return validate.FieldErrors{{Field: "direction", Error: "must be one of [any from to]"}}
+	}
+
+	pq, err := parsePageQuery(r, "tip", "nonce", "timestamp")
+	if err != nil {
+		return err
+	}
+
 	mempool := h.State.Mempool()
 
 	trans := []tx{}
 	for _, tran := range mempool {
-		if acct != "" && ((acct != string(tran.FromID)) && (acct != string(tran.ToID))) {
-			continue
+		if acct != "" {
+			from := acct == string(tran.FromID)
+			to := acct == string(tran.ToID)
+
+			switch direction {
+			case "from":
+				if !from {
+					continue
+				}
+			case "to":
+				if !to {
+					continue
+				}
+			default:
+				if !from && !to {
+					continue
+				}
+			}
 		}
 
-		trans = append(trans, tx{
-			FromAccount: tran.FromID,
-			FromName:    h.NS.Lookup(tran.FromID),
-			To:          tran.ToID,
-			ToName:      h.NS.Lookup(tran.ToID),
-			ChainID:     tran.ChainID,
-			Nonce:       tran.Nonce,
-			Value:       tran.Value,
-			Tip:         tran.Tip,
-			Data:        tran.Data,
-			TimeStamp:   tran.TimeStamp,
-			GasPrice:    tran.GasPrice,
-			GasUnits:    tran.GasUnits,
-			Sig:         tran.SignatureString(),
-		})
+		trans = append(trans, newTx(h.NS, tran))
+	}
+
+	sortTxs(trans, pq)
+
+	start, end, next := pq.bounds(len(trans))
+	resp := page{
+		Items:      trans[start:end],
+		Total:      len(trans),
+		NextCursor: next,
+	}
+
+	return web.Respond(ctx, w, resp, http.StatusOK)
+}
+
+// TransactionStatus returns whether the transaction identified by the account
+// and nonce is pending in the mempool or was dropped.
+func (h Handlers) TransactionStatus(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	accountID, err := database.ToAccountID(web.Param(r, "account"))
+	if err != nil {
+		return validate.NewRequestError(err, http.StatusBadRequest)
+	}
+
+	nonce, err := strconv.ParseUint(web.Param(r, "nonce"), 10, 64)
+	if err != nil {
+		return validate.NewRequestError(fmt.Errorf("invalid nonce: %w", err), http.StatusBadRequest)
+	}
+
+	if tran, err := h.State.QueryMempoolTx(accountID, nonce); err == nil {
+		t := newTx(h.NS, tran)
+		return web.Respond(ctx, w, txStatus{Hash: tran.TxHash(), Status: statusPending, Tx: &t}, http.StatusOK)
 	}
 
-	return web.Respond(ctx, w, trans, http.StatusOK)
+	// A transaction that isn't in the mempool was dropped or never received.
+	return web.Respond(ctx, w, txStatus{Status: statusDropped}, http.StatusOK)
 }
 
-// Accounts returns the current balances for all users.
+// Accounts returns the current balances for all users. The listing of all
+// accounts is paged, can be sorted by account, balance or nonce and filtered
+// with a minimum balance.
 func (h Handlers) Accounts(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
 	accountStr := web.Param(r, "account")
 
-	var accounts = make(map[database.AccountID]database.Account)
-	switch accountStr {
-	case "":
-		accounts = h.State.Accounts()
-	default:
+	if accountStr != "" {
 		accountID, err := database.ToAccountID(accountStr)
 		if err != nil {
 			return err
@@ -110,11 +338,199 @@ func (h Handlers) Accounts(ctx context.Context, w http.ResponseWriter, r *http.R
 
 		account, err := h.State.QueryAccount(accountID)
 		if err != nil {
-			return err
+			return validate.NewRequestError(fmt.Errorf("account %s not found", accountID), http.StatusNotFound)
+		}
+
+		accounts := map[database.AccountID]database.Account{accountID: account}
+		return web.Respond(ctx, w, accounts, http.StatusOK)
+	}
+
+	pq, err := parsePageQuery(r, "account", "balance", "nonce")
+	if err != nil {
+		return err
+	}
+
+	var minBalance uint64
+	if mb := r.URL.Query().Get("min_balance"); mb != "" {
+		if minBalance, err = strconv.ParseUint(mb, 10, 64); err != nil {
+			return validate.FieldErrors{{Field: "min_balance", Error: "must be a positive number"}}
+		}
+	}
+
+	accounts := []account{}
+	for accountID, acct := range h.State.Accounts() {
+		if acct.Balance < minBalance {
+			continue
+		}
+
+		accounts = append(accounts, account{
+			Account: accountID,
+			Name:    h.NS.Lookup(accountID),
+			Balance: acct.Balance,
+			Nonce:   acct.Nonce,
+		})
+	}
+
+	// The accounts come from a map, so they are always sorted to keep the
+	// pages stable between calls.
+	sort.Slice(accounts, func(i, j int) bool {
+		var cmp int
+		switch pq.sort {
+		case "balance":
+			cmp = compare(accounts[i].Balance, accounts[j].Balance)
+		case "nonce":
+			cmp = compare(accounts[i].Nonce, accounts[j].Nonce)
+		}
+		if cmp == 0 {
+			cmp = strings.Compare(string(accounts[i].Account), string(accounts[j].Account))
+		}
+		return pq.less(cmp)
+	})
+
+	start, end, next := pq.bounds(len(accounts))
+	resp := page{
+		Items:      accounts[start:end],
+		Total:      len(accounts),
+		NextCursor: next,
+	}
+
+	return web.Respond(ctx, w, resp, http.StatusOK)
+}
+
+// Transaction returns the transaction in the mempool with the specified hash.
+func (h Handlers) Transaction(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	hash := web.Param(r, "hash")
+
+	tran, err := h.State.QueryMempoolHash(hash)
+	if err != nil {
+		return validate.NewRequestError(fmt.Errorf("transaction %s not found", hash), http.StatusNotFound)
+	}
+
+	t := newTx(h.NS, tran)
+	return web.Respond(ctx, w, txStatus{Hash: hash, Status: statusPending, Tx: &t}, http.StatusOK)
+}
+
+// Names returns the registry of account names known to the name service.
+func (h Handlers) Names(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	return web.Respond(ctx, w, h.NS.Names(), http.StatusOK)
+}
+
+// NameList returns the page of names known to the name service, sorted by
+// name.
+func (h Handlers) NameList(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	pq, err := parsePageQuery(r, "name")
+	if err != nil {
+		return err
+	}
+
+	names := []name{}
+	for n, accountID := range h.NS.Names() {
+		names = append(names, name{Name: n, Account: accountID})
+	}
+
+	// The names come from a map, so they are always sorted to keep the pages
+	// stable between calls.
+	sort.Slice(names, func(i, j int) bool {
+		return pq.less(strings.Compare(names[i].Name, names[j].Name))
+	})
+
+	start, end, next := pq.bounds(len(names))
+	resp := page{
+		Items:      names[start:end],
+		Total:      len(names),
+		NextCursor: next,
+	}
+
+	return web.Respond(ctx, w, resp, http.StatusOK)
+}
+
+// NameLookup returns the name and account for the specified name. An account
+// can be provided instead to find its name.
+func (h Handlers) NameLookup(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	key := web.Param(r, "name")
+
+	if accountID, exists := h.NS.Resolve(key); exists {
+		return web.Respond(ctx, w, name{Name: key, Account: accountID}, http.StatusOK)
+	}
+
+	// The names are kept by the checksummed account, so an account in any
+	// case is found.
+	if _, err := database.ToAccountID(key); err == nil {
+		accountID := database.AccountID(common.HexToAddress(key).Hex())
+		if n := h.NS.Lookup(accountID); n != string(accountID) {
+			return web.Respond(ctx, w, name{Name: n, Account: accountID}, http.StatusOK)
+		}
+	}
+
+	return validate.NewRequestError(fmt.Errorf("name %s not found", key), http.StatusNotFound)
+}
+
+// VerifySignature recovers the account that signed a message with the Ardan
+// stamp. When an account is provided, the signer must match it.
+func (h Handlers) VerifySignature(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	var sm signedMessage
+	if err := web.Decode(r, &sm); err != nil {
+		return validate.NewRequestError(
+			fmt.Errorf("invalid payload to decode: %w", err), http.StatusBadRequest)
+	}
+
+	address, err := signature.VerifyMessage(sm.Message, sm.Signature)
+	if err != nil {
+		return validate.NewRequestError(err, http.StatusBadRequest)
+	}
+
+	signer := database.AccountID(address)
+	resp := verifiedMessage{
+		Account: signer,
+		Name:    h.NS.Lookup(signer),
+	}
+
+	if sm.Account != "" {
+		valid := strings.EqualFold(string(sm.Account), address)
+		resp.Valid = &valid
+	}
+
+	return web.Respond(ctx, w, resp, http.StatusOK)
+}
+
+// =============================================================================
+
+// sortTxs orders the transactions by the sort field of the page query. The
+// mempool comes from a map, so the transactions are always sorted by account
+// and nonce after the sort field, or when there is no sort field, to page
+// through the same order each time.
+func sortTxs(trans []tx, pq pageQuery) {
+	sort.Slice(trans, func(i, j int) bool {
+		var cmp int
+		switch pq.sort {
+		case "tip":
+			cmp = compare(trans[i].Tip, trans[j].Tip)
+		case "nonce":
+			cmp = compare(trans[i].Nonce, trans[j].Nonce)
+		case "timestamp":
+			cmp = compare(trans[i].TimeStamp, trans[j].TimeStamp)
 		}
+		if cmp == 0 {
+			cmp = strings.Compare(string(trans[i].FromAccount), string(trans[j].FromAccount))
+		}
+		if cmp == 0 {
+			cmp = compare(trans[i].Nonce, trans[j].Nonce)
+		}
+		return pq.less(cmp)
+	})
+}
 
-		accounts = map[database.AccountID]database.Account{accountID: account}
+// queryList returns the values of the query parameter, which can be repeated
+// or hold a comma separated list.
+func queryList(r *http.Request, key string) []string {
+	var values []string
+	for _, value := range r.URL.Query()[key] {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
 	}
 
-	return web.Respond(ctx, w, accounts, http.StatusOK)
+	return values
 }
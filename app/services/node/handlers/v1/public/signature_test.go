@@ -0,0 +1,134 @@
+package public_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/app/services/node/handlers/v1/public"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/sys/validate"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/web/v1/mid"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/signature"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/nameservice"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/web"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+)
+
+func TestVerifySignature(t *testing.T) {
+	ns, err := nameservice.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("Should be able to construct the name service: %s", err)
+	}
+
+	log := zap.NewNop().Sugar()
+	h := public.Handlers{Log: log, NS: ns}
+
+	app := web.NewApp(nil, nil, validate.Check, mid.Errors(log))
+	app.Handle(http.MethodPost, "v1", "/signature/verify", h.VerifySignature)
+
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Should be able to generate a private key: %s", err)
+	}
+	signer := crypto.PubkeyToAddress(privateKey.PublicKey).String()
+
+	v, r, s, err := signature.Sign("hello", privateKey)
+	if err != nil {
+		t.Fatalf("Should be able to sign the message: %s", err)
+	}
+	sig := signature.SignatureString(v, r, s)
+
+	// resp holds the fields of the response the test checks. Valid is
+	// missing when no account is given.
+	type resp struct {
+		Account string `json:"account"`
+		Valid   *bool  `json:"valid"`
+	}
+	yes, no := true, false
+
+	tt := []struct {
+		name    string
+		account string
+		valid   *bool
+	}{
+		{name: "no account"},
+		{name: "signer", account: signer, valid: &yes},
+		{name: "signer lowercase", account: strings.ToLower(signer), valid: &yes},
+		{name: "other account", account: "0xF01813E4B85e178A83e29B8E7bF26BD830a25f32", valid: &no},
+	}
+
+	for _, tst := range tt {
+		t.Run(tst.name, func(t *testing.T) {
+			body, _ := json.Marshal(map[string]string{"message": "hello", "signature": sig, "account": tst.account})
+
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/signature/verify", strings.NewReader(string(body))))
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Should get status %d, got %d: %s", http.StatusOK, w.Code, w.Body)
+			}
+
+			var got resp
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("Should be able to decode the response: %s", err)
+			}
+
+			if got.Account != signer {
+				t.Errorf("Should get the signer, got %s, exp %s", got.Account, signer)
+			}
+
+			switch {
+			case tst.valid == nil && got.Valid != nil:
+				t.Errorf("Should not report valid without an account, got %v", *got.Valid)
+			case tst.valid != nil && (got.Valid == nil || *got.Valid != *tst.valid):
+				t.Errorf("Should report valid as %v, got %v", *tst.valid, got.Valid)
+			}
+		})
+	}
+
+	t.Run("bad signature", func(t *testing.T) {
+		body := `{"message":"hello","signature":"0x1234"}`
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/signature/verify", strings.NewReader(body)))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Should get status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+}
+
+func TestVerifySignatureValidation(t *testing.T) {
+	ns, err := nameservice.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("Should be able to construct the name service: %s", err)
+	}
+
+	log := zap.NewNop().Sugar()
+	h := public.Handlers{Log: log, NS: ns}
+
+	// The validator belongs to the app, so an app without one doesn't
+	// check the request model.
+	checked := web.NewApp(nil, nil, validate.Check, mid.Errors(log))
+	checked.Handle(http.MethodPost, "v1", "/signature/verify", h.VerifySignature)
+
+	unchecked := web.NewApp(nil, nil, nil, mid.Errors(log))
+	unchecked.Handle(http.MethodPost, "v1", "/signature/verify", h.VerifySignature)
+
+	body := `{"message":"hello","signature":""}`
+
+	w := httptest.NewRecorder()
+	checked.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/signature/verify", strings.NewReader(body)))
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `"signature"`) {
+		t.Errorf("Should get a field error for the signature, got %d: %s", w.Code, w.Body)
+	}
+
+	w = httptest.NewRecorder()
+	unchecked.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/signature/verify", strings.NewReader(body)))
+	if strings.Contains(w.Body.String(), "data validation error") {
+		t.Errorf("Should not validate the model without a validator, got %d: %s", w.Code, w.Body)
+	}
+}
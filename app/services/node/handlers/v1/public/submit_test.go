@@ -0,0 +1,108 @@
+package public_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/app/services/node/handlers/v1/public"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/sys/validate"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/web/v1/mid"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/bus"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/genesis"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/state"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/ratelimit"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/web"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+)
+
+func TestSubmitAccountLimit(t *testing.T) {
+	victimKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Should be able to generate the victim key: %s", err)
+	}
+	attackerKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Should be able to generate the attacker key: %s", err)
+	}
+	victim := database.PublicKeyToAccountID(victimKey.PublicKey)
+
+	evts := bus.New(func(ctx context.Context) string { return "" })
+	defer evts.Shutdown()
+
+	st, err := state.New(context.Background(), state.Config{
+		Genesis:        genesis.Genesis{ChainID: 1, Balances: map[string]uint64{string(victim): 1000}},
+		Bus:            evts,
+		SelectStrategy: "tip",
+	})
+	if err != nil {
+		t.Fatalf("Should be able to construct the state: %s", err)
+	}
+
+	log := zap.NewNop().Sugar()
+	h := public.Handlers{
+		Log:   log,
+		State: st,
+
+		// Each account can submit one transaction.
+		AccountLimiter: ratelimit.New(0.0001, 1),
+	}
+
+	app := web.NewApp(nil, nil, validate.Check, mid.Errors(log))
+	app.Handle(http.MethodPost, "v1", "/tx/submit", h.SubmitWalletTransaction)
+
+	// submit posts a transaction from the victim signed with the key.
+	submit := func(t *testing.T, nonce uint64, key *ecdsa.PrivateKey) int {
+		tx, err := database.NewTx(1, nonce, victim, "0xbEE6ACE826eC3DE1B6349888B9151B92522F7F76", 1, 0, nil)
+		if err != nil {
+			t.Fatalf("Should be able to construct the transaction: %s", err)
+		}
+
+		signed, err := tx.Sign(key)
+		if err != nil {
+			t.Fatalf("Should be able to sign the transaction: %s", err)
+		}
+
+		body, err := json.Marshal(signed)
+		if err != nil {
+			t.Fatalf("Should be able to encode the transaction: %s", err)
+		}
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/tx/submit", bytes.NewReader(body)))
+		return w.Code
+	}
+
+	// Transactions forged by the attacker are rejected for their signature
+	// without using up the victim's allowance.
+	for i := 0; i < 3; i++ {
+		if code := submit(t, 1, attackerKey); code != http.StatusBadRequest {
+			t.Fatalf("Should reject the forged transaction with %d, got %d", http.StatusBadRequest, code)
+		}
+	}
+
+	if code := submit(t, 1, victimKey); code != http.StatusOK {
+		t.Fatalf("Should accept the victim's own transaction, got %d", code)
+	}
+
+	if code := submit(t, 2, victimKey); code != http.StatusTooManyRequests {
+		t.Errorf("Should limit the victim's second transaction with %d, got %d", http.StatusTooManyRequests, code)
+	}
+
+	t.Run("body too large", func(t *testing.T) {
+		body := `{"data":"` + strings.Repeat("a", 2<<20) + `"}`
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/tx/submit", strings.NewReader(body)))
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("Should reject the body with %d, got %d", http.StatusRequestEntityTooLarge, w.Code)
+		}
+	})
+}
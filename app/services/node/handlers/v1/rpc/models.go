@@ -0,0 +1,106 @@
+package rpc
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// version is the only JSON-RPC version supported.
+const version = "2.0"
+
+// Set of error codes defined by the JSON-RPC 2.0 specification along with
+// the code used by Ethereum nodes for a rejected transaction.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+	codeServerError    = -32000
+	codeLimitExceeded  = -32005
+)
+
+// rejectMalformed is the reason recorded for a submitted transaction that
+// couldn't be decoded or failed field validation.
+const rejectMalformed = "malformed"
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// isNotification reports if the request has no id, in which case the client
+// doesn't expect a response.
+func (r request) isNotification() bool {
+	return r.ID == nil
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+// Error implements the error interface.
+func (err *rpcError) Error() string {
+	return err.Message
+}
+
+// newError constructs an error with the specified code.
+func newError(code int, message string) *rpcError {
+	return &rpcError{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// transaction represents a transaction in the form Ethereum tooling expects.
+// Transactions are only available while they are in the mempool, so the
+// block fields are always null.
+type transaction struct {
+	Hash             string          `json:"hash"`
+	Nonce            hexutil.Uint64  `json:"nonce"`
+	BlockHash        *string         `json:"blockHash"`
+	BlockNumber      *hexutil.Uint64 `json:"blockNumber"`
+	TransactionIndex *hexutil.Uint64 `json:"transactionIndex"`
+	From             string          `json:"from"`
+	To               string          `json:"to"`
+	Value            *hexutil.Big    `json:"value"`
+	Gas              hexutil.Uint64  `json:"gas"`
+	GasPrice         *hexutil.Big    `json:"gasPrice"`
+	Input            hexutil.Bytes   `json:"input"`
+	ChainID          hexutil.Uint64  `json:"chainId"`
+	V                *hexutil.Big    `json:"v"`
+	R                *hexutil.Big    `json:"r"`
+	S                *hexutil.Big    `json:"s"`
+}
+
+// newTransaction converts a block transaction into the transaction model.
+func newTransaction(tran database.BlockTx) transaction {
+	return transaction{
+		Hash:     tran.TxHash(),
+		Nonce:    hexutil.Uint64(tran.Nonce),
+		From:     string(tran.FromID),
+		To:       string(tran.ToID),
+		Value:    (*hexutil.Big)(new(big.Int).SetUint64(tran.Value)),
+		Gas:      hexutil.Uint64(tran.GasUnits),
+		GasPrice: (*hexutil.Big)(new(big.Int).SetUint64(tran.GasPrice)),
+		Input:    tran.Data,
+		ChainID:  hexutil.Uint64(tran.ChainID),
+		V:        (*hexutil.Big)(tran.V),
+		R:        (*hexutil.Big)(tran.R),
+		S:        (*hexutil.Big)(tran.S),
+	}
+}
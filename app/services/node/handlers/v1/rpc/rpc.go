@@ -0,0 +1,368 @@
+// Package rpc maintains the handler for the Ethereum compatible JSON-RPC 2.0
+// api. A useful subset of the Ethereum methods is mapped onto the state so
+// existing Ethereum tooling can read from the node.
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/sys/validate"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/web/metrics"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/state"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/ratelimit"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/web"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/zap"
+)
+
+// maxBodySize is the largest request body accepted, which covers a batch of
+// a few hundred transactions.
+const maxBodySize = 1 << 20
+
+// method represents the function that implements a JSON-RPC method.
+type method func(ctx context.Context, params json.RawMessage) (any, error)
+
+// Handlers manages the set of JSON-RPC endpoints.
+type Handlers struct {
+	Log   *zap.SugaredLogger
+	State *state.State
+
+	// Limiters for transaction submission by remote IP and sending account.
+	// Every transaction in a batch is charged, so a batch can't be used to
+	// get around the limits. A nil limiter doesn't limit anything.
+	IPLimiter      *ratelimit.Limiter
+	AccountLimiter *ratelimit.Limiter
+}
+
+// ctxKey represents the type of value for the context key.
+type ctxKey int
+
+// ipKey is how the remote IP is stored in the context for the methods.
+const ipKey ctxKey = 1
+
+// RPC handles a single JSON-RPC request or a batch of them. Errors are
+// reported in the JSON-RPC response, so the status code is always 200 unless
+// the request only held notifications.
+func (h Handlers) RPC(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	ctx = context.WithValue(ctx, ipKey, web.RemoteIP(r))
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		return validate.NewRequestError(fmt.Errorf("unable to read request: %w", err), http.StatusBadRequest)
+	}
+
+	if len(body) > maxBodySize {
+		return validate.NewRequestError(errors.New("request is too large"), http.StatusRequestEntityTooLarge)
+	}
+
+	// A batch is a JSON array of requests.
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(trimmed, &batch); err != nil {
+			return web.Respond(ctx, w, errorResponse(nil, newError(codeParseError, "parse error")), http.StatusOK)
+		}
+
+		if len(batch) == 0 {
+			return web.Respond(ctx, w, errorResponse(nil, newError(codeInvalidRequest, "empty batch")), http.StatusOK)
+		}
+
+		resps := []response{}
+		for _, raw := range batch {
+			if resp, ok := h.call(ctx, raw); ok {
+				resps = append(resps, resp)
+			}
+		}
+
+		if len(resps) == 0 {
+			return web.Respond(ctx, w, nil, http.StatusNoContent)
+		}
+
+		return web.Respond(ctx, w, resps, http.StatusOK)
+	}
+
+	resp, ok := h.call(ctx, body)
+	if !ok {
+		return web.Respond(ctx, w, nil, http.StatusNoContent)
+	}
+
+	return web.Respond(ctx, w, resp, http.StatusOK)
+}
+
+// call executes a single request. It returns false when the request is a
+// notification and no response should be sent.
+func (h Handlers) call(ctx context.Context, raw json.RawMessage) (response, bool) {
+	var req request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		if !json.Valid(raw) {
+			return errorResponse(nil, newError(codeParseError, "parse error")), true
+		}
+		return errorResponse(nil, newError(codeInvalidRequest, "invalid request")), true
+	}
+
+	if req.JSONRPC != version || req.Method == "" {
+		return errorResponse(req.ID, newError(codeInvalidRequest, "invalid request")), true
+	}
+
+	var result any
+	var err error
+	fn, exists := h.methods()[req.Method]
+	if exists {
+		result, err = fn(ctx, req.Params)
+	} else {
+		err = newError(codeMethodNotFound, fmt.Sprintf("method %s not found", req.Method))
+	}
+
+	if req.isNotification() {
+		return response{}, false
+	}
+
+	if err != nil {
+		var rerr *rpcError
+		if !errors.As(err, &rerr) {
+			h.Log.Errorw("rpc", "traceid", web.GetTraceID(ctx), "method", req.Method, "ERROR", err)
+			rerr = newError(codeInternalError, "internal error")
+		}
+		return errorResponse(req.ID, rerr), true
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		h.Log.Errorw("rpc", "traceid", web.GetTraceID(ctx), "method", req.Method, "ERROR", err)
+		return errorResponse(req.ID, newError(codeInternalError, "internal error")), true
+	}
+
+	return response{JSONRPC: version, Result: data, ID: req.ID}, true
+}
+
+// methods returns the set of supported methods.
+func (h Handlers) methods() map[string]method {
+	return map[string]method{
+		"eth_chainId":              h.chainID,
+		"eth_blockNumber":          h.blockNumber,
+		"eth_getBalance":           h.getBalance,
+		"eth_getTransactionCount":  h.getTransactionCount,
+		"eth_getBlockByNumber":     h.getBlockByNumber,
+		"eth_getTransactionByHash": h.getTransactionByHash,
+		"ardan_sendTransaction":    h.sendTransaction,
+	}
+}
+
+// =============================================================================
+
+// chainID returns the chain id from the genesis.
+func (h Handlers) chainID(ctx context.Context, params json.RawMessage) (any, error) {
+	return hexutil.Uint64(h.State.Genesis().ChainID), nil
+}
+
+// blockNumber returns the number of the latest block. Blocks are not stored
+// yet, so this is always the genesis block.
+func (h Handlers) blockNumber(ctx context.Context, params json.RawMessage) (any, error) {
+	return hexutil.Uint64(0), nil
+}
+
+// getBalance returns the balance of the account. Only the latest state is
+// available, so it is returned for any block.
+func (h Handlers) getBalance(ctx context.Context, params json.RawMessage) (any, error) {
+	var address, block string
+	if err := parseParams(params, 1, &address, &block); err != nil {
+		return nil, err
+	}
+
+	accountID, err := toAccountID(address)
+	if err != nil {
+		return nil, err
+	}
+
+	// An account the node doesn't know about has a balance of zero.
+	var balance uint64
+	if account, err := h.State.QueryAccount(accountID); err == nil {
+		balance = account.Balance
+	}
+
+	return (*hexutil.Big)(new(big.Int).SetUint64(balance)), nil
+}
+
+// getTransactionCount returns the number of transactions sent by the account.
+// For the pending block the transactions in the mempool are included. The
+// next Ardan nonce for the account is the count plus one.
+func (h Handlers) getTransactionCount(ctx context.Context, params json.RawMessage) (any, error) {
+	var address, block string
+	if err := parseParams(params, 1, &address, &block); err != nil {
+		return nil, err
+	}
+
+	accountID, err := toAccountID(address)
+	if err != nil {
+		return nil, err
+	}
+
+	var count uint64
+	if account, err := h.State.QueryAccount(accountID); err == nil {
+		count = account.Nonce
+	}
+
+	if block == "pending" {
+		for _, tran := range h.State.Mempool() {
+			if tran.FromID == accountID {
+				count++
+			}
+		}
+	}
+
+	return hexutil.Uint64(count), nil
+}
+
+// getBlockByNumber returns the block with the specified number. Blocks are
+// not stored yet, so no block is ever found.
+func (h Handlers) getBlockByNumber(ctx context.Context, params json.RawMessage) (any, error) {
+	var block string
+	var full bool
+	if err := parseParams(params, 1, &block, &full); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// getTransactionByHash returns the transaction with the specified hash.
+// Transactions can only be found while they are in the mempool.
+func (h Handlers) getTransactionByHash(ctx context.Context, params json.RawMessage) (any, error) {
+	var hash string
+	if err := parseParams(params, 1, &hash); err != nil {
+		return nil, err
+	}
+
+	tran, err := h.State.QueryMempoolHash(hash)
+	if err != nil {
+		return nil, nil
+	}
+
+	return newTransaction(tran), nil
+}
+
+// sendTransaction adds an Ardan signed transaction to the mempool and returns
+// the hash of the transaction.
+func (h Handlers) sendTransaction(ctx context.Context, params json.RawMessage) (any, error) {
+	var signedTx database.SignedTx
+	if err := parseParams(params, 1, &signedTx); err != nil {
+		metrics.AddTxRejected(ctx, rejectMalformed)
+		return nil, err
+	}
+
+	if err := validate.Check(signedTx); err != nil {
+		metrics.AddTxRejected(ctx, rejectMalformed)
+		rerr := newError(codeInvalidParams, "data validation error")
+		rerr.Data = validate.GetFieldErrors(err).Fields()
+		return nil, rerr
+	}
+
+	if err := h.allow(ctx, signedTx); err != nil {
+		return nil, err
+	}
+
+	h.Log.Infow("add tran", "traceid", web.GetTraceID(ctx), "sig:nonce", signedTx, "from", signedTx.FromID, "to", signedTx.ToID, "value", signedTx.Value, "tip", signedTx.Tip)
+
+	hash, err := h.State.UpsertWalletTransaction(ctx, signedTx)
+	if err != nil {
+		var re *state.RejectedError
+		if errors.As(err, &re) {
+			metrics.AddTxRejected(ctx, re.Reason)
+		}
+		return nil, newError(codeServerError, err.Error())
+	}
+
+	return hash, nil
+}
+
+// allow takes a token from the remote IP and the sending account for the
+// transaction. Only a transaction with a good signature is charged to the
+// sending account, so nobody can use up the allowance of another account. A
+// transaction that fails is left for the state to reject. The error tells
+// the caller how long to wait before trying again.
+func (h Handlers) allow(ctx context.Context, signedTx database.SignedTx) error {
+	ip, _ := ctx.Value(ipKey).(string)
+	if ok, wait := h.IPLimiter.Allow(ip); !ok {
+		metrics.AddRateLimitedIPs(ctx)
+		return limitExceeded(fmt.Sprintf("too many requests from %s", ip), wait)
+	}
+
+	if signedTx.Validate(h.State.Genesis().ChainID) != nil {
+		return nil
+	}
+
+	from := signedTx.FromID
+	if ok, wait := h.AccountLimiter.Allow(strings.ToLower(string(from))); !ok {
+		metrics.AddRateLimitedAccounts(ctx)
+		return limitExceeded(fmt.Sprintf("too many transactions from %s", from), wait)
+	}
+
+	return nil
+}
+
+// limitExceeded constructs the error for a rate limited call, with the number
+// of seconds to wait in the data.
+func limitExceeded(msg string, wait time.Duration) *rpcError {
+	seconds := ratelimit.RetryAfter(wait)
+
+	rerr := newError(codeLimitExceeded, fmt.Sprintf("%s, retry after %ds", msg, seconds))
+	rerr.Data = struct {
+		RetryAfter int `json:"retry_after"`
+	}{seconds}
+
+	return rerr
+}
+
+// =============================================================================
+
+// parseParams decodes the positional params into the destinations. The
+// first required number of params must be provided.
+func parseParams(params json.RawMessage, required int, dest ...any) error {
+	var list []json.RawMessage
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &list); err != nil {
+			return newError(codeInvalidParams, "params must be an array")
+		}
+	}
+
+	if len(list) < required || len(list) > len(dest) {
+		return newError(codeInvalidParams, fmt.Sprintf("expected between %d and %d params, got %d", required, len(dest), len(list)))
+	}
+
+	for i, raw := range list {
+		if err := json.Unmarshal(raw, dest[i]); err != nil {
+			return newError(codeInvalidParams, fmt.Sprintf("invalid param %d: %s", i, err))
+		}
+	}
+
+	return nil
+}
+
+// toAccountID converts an address in any case into the checksummed account
+// used by the state.
+func toAccountID(address string) (database.AccountID, error) {
+	if !common.IsHexAddress(address) {
+		return "", newError(codeInvalidParams, fmt.Sprintf("invalid address %q", address))
+	}
+
+	return database.AccountID(common.HexToAddress(address).Hex()), nil
+}
+
+// errorResponse constructs the response for a failed request.
+func errorResponse(id json.RawMessage, err *rpcError) response {
+	return response{
+		JSONRPC: version,
+		Error:   err,
+		ID:      id,
+	}
+}
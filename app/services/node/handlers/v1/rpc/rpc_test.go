@@ -0,0 +1,294 @@
+package rpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/web/v1/mid"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/bus"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/genesis"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/state"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/ratelimit"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/web"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+)
+
+// newApp constructs the handlers over a state where the private key's
+// account has a balance, and binds them to an app like the node does.
+func newApp(t *testing.T, privateKey *ecdsa.PrivateKey, h Handlers) *web.App {
+	evts := bus.New(func(ctx context.Context) string { return "" })
+	t.Cleanup(evts.Shutdown)
+
+	st, err := state.New(context.Background(), state.Config{
+		Genesis: genesis.Genesis{
+			ChainID:  1,
+			Balances: map[string]uint64{string(database.PublicKeyToAccountID(privateKey.PublicKey)): 1000},
+		},
+		Bus:            evts,
+		SelectStrategy: "tip",
+	})
+	if err != nil {
+		t.Fatalf("Should be able to construct the state: %s", err)
+	}
+
+	log := zap.NewNop().Sugar()
+	h.Log = log
+	h.State = st
+
+	app := web.NewApp(nil, nil, nil, mid.Errors(log))
+	app.Handle(http.MethodPost, "v1", "/rpc", h.RPC)
+
+	return app
+}
+
+// post sends the body to the rpc route.
+func post(app *web.App, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/rpc", strings.NewReader(body)))
+	return w
+}
+
+// signedTx returns the params for ardan_sendTransaction with a transaction
+// signed by the private key.
+func signedTx(t *testing.T, privateKey *ecdsa.PrivateKey, nonce uint64) string {
+	tx, err := database.NewTx(1, nonce, database.PublicKeyToAccountID(privateKey.PublicKey), "0xbEE6ACE826eC3DE1B6349888B9151B92522F7F76", 1, 0, nil)
+	if err != nil {
+		t.Fatalf("Should be able to construct the transaction: %s", err)
+	}
+
+	signed, err := tx.Sign(privateKey)
+	if err != nil {
+		t.Fatalf("Should be able to sign the transaction: %s", err)
+	}
+
+	data, err := json.Marshal([]any{signed})
+	if err != nil {
+		t.Fatalf("Should be able to encode the transaction: %s", err)
+	}
+
+	return string(data)
+}
+
+func TestRPC(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Should be able to generate a private key: %s", err)
+	}
+
+	app := newApp(t, privateKey, Handlers{})
+
+	tt := []struct {
+		name string
+		body string
+		code int
+	}{
+		{"parse error", `{"jsonrpc":"2.0","method":`, codeParseError},
+		{"batch parse error", `[{"jsonrpc":"2.0","method":"eth_chainId","id":1},`, codeParseError},
+		{"empty batch", `[]`, codeInvalidRequest},
+		{"wrong version", `{"jsonrpc":"1.0","method":"eth_chainId","id":1}`, codeInvalidRequest},
+		{"no method", `{"jsonrpc":"2.0","id":1}`, codeInvalidRequest},
+		{"not an object", `42`, codeInvalidRequest},
+		{"unknown method", `{"jsonrpc":"2.0","method":"eth_mining","id":1}`, codeMethodNotFound},
+		{"params not an array", `{"jsonrpc":"2.0","method":"eth_getBalance","params":{},"id":1}`, codeInvalidParams},
+		{"missing params", `{"jsonrpc":"2.0","method":"eth_getBalance","params":[],"id":1}`, codeInvalidParams},
+		{"bad address", `{"jsonrpc":"2.0","method":"eth_getBalance","params":["0x12"],"id":1}`, codeInvalidParams},
+		{"unsigned transaction", `{"jsonrpc":"2.0","method":"ardan_sendTransaction","params":[{"chain_id":1}],"id":1}`, codeInvalidParams},
+	}
+
+	for _, tst := range tt {
+		t.Run(tst.name, func(t *testing.T) {
+			w := post(app, tst.body)
+			if w.Code != http.StatusOK {
+				t.Fatalf("Should get status %d, got %d", http.StatusOK, w.Code)
+			}
+
+			var resp response
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Should be able to decode the response: %s", err)
+			}
+
+			if resp.Error == nil || resp.Error.Code != tst.code {
+				t.Errorf("Should get error code %d, got %+v", tst.code, resp.Error)
+			}
+		})
+	}
+
+	t.Run("notifications only", func(t *testing.T) {
+		w := post(app, `[{"jsonrpc":"2.0","method":"eth_chainId"},{"jsonrpc":"2.0","method":"eth_blockNumber"}]`)
+		if w.Code != http.StatusNoContent {
+			t.Errorf("Should get status %d for a batch of notifications, got %d", http.StatusNoContent, w.Code)
+		}
+
+		w = post(app, `{"jsonrpc":"2.0","method":"eth_chainId"}`)
+		if w.Code != http.StatusNoContent {
+			t.Errorf("Should get status %d for a notification, got %d", http.StatusNoContent, w.Code)
+		}
+	})
+
+	t.Run("mixed batch", func(t *testing.T) {
+		body := `[
+			{"jsonrpc":"2.0","method":"eth_chainId","id":1},
+			{"jsonrpc":"2.0","method":"eth_blockNumber"},
+			{"jsonrpc":"2.0","method":"eth_mining","id":"two"},
+			7,
+			{"jsonrpc":"2.0","method":"ardan_sendTransaction","params":` + signedTx(t, privateKey, 1) + `,"id":3}
+		]`
+
+		w := post(app, body)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Should get status %d, got %d", http.StatusOK, w.Code)
+		}
+
+		var resps []response
+		if err := json.NewDecoder(w.Body).Decode(&resps); err != nil {
+			t.Fatalf("Should be able to decode the responses: %s", err)
+		}
+
+		// The notification gets no response, the rest are in order.
+		exp := []struct {
+			id   string
+			code int
+		}{
+			{`1`, 0},
+			{`"two"`, codeMethodNotFound},
+			{`null`, codeInvalidRequest},
+			{`3`, 0},
+		}
+
+		if len(resps) != len(exp) {
+			t.Fatalf("Should get %d responses, got %d", len(exp), len(resps))
+		}
+
+		for i, resp := range resps {
+			if string(resp.ID) != exp[i].id {
+				t.Errorf("Should get id %s for response %d, got %s", exp[i].id, i, resp.ID)
+			}
+
+			var code int
+			if resp.Error != nil {
+				code = resp.Error.Code
+			}
+			if code != exp[i].code {
+				t.Errorf("Should get error code %d for response %d, got %+v", exp[i].code, i, resp.Error)
+			}
+		}
+
+		if got := string(resps[0].Result); got != `"0x1"` {
+			t.Errorf("Should get the chain id, got %s", got)
+		}
+	})
+}
+
+func TestRPCRateLimit(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Should be able to generate a private key: %s", err)
+	}
+
+	// The IP can submit three transactions. The limiter never refills
+	// during the test.
+	app := newApp(t, privateKey, Handlers{IPLimiter: ratelimit.New(0.0001, 3)})
+
+	var calls []string
+	for nonce := uint64(1); nonce <= 5; nonce++ {
+		calls = append(calls, fmt.Sprintf(`{"jsonrpc":"2.0","method":"ardan_sendTransaction","params":%s,"id":%d}`, signedTx(t, privateKey, nonce), nonce))
+	}
+
+	// Reads aren't charged, so they don't use up the limit.
+	calls = append(calls, `{"jsonrpc":"2.0","method":"eth_chainId","id":6}`)
+
+	w := post(app, "["+strings.Join(calls, ",")+"]")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Should get status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var resps []response
+	if err := json.NewDecoder(w.Body).Decode(&resps); err != nil {
+		t.Fatalf("Should be able to decode the responses: %s", err)
+	}
+
+	if len(resps) != 6 {
+		t.Fatalf("Should get 6 responses, got %d", len(resps))
+	}
+
+	for i, resp := range resps {
+		limited := i == 3 || i == 4
+		switch {
+		case limited && (resp.Error == nil || resp.Error.Code != codeLimitExceeded):
+			t.Errorf("Should limit call %d, got %+v", i+1, resp.Error)
+
+		case limited:
+			data, _ := json.Marshal(resp.Error.Data)
+			var retry struct {
+				RetryAfter int `json:"retry_after"`
+			}
+			if err := json.Unmarshal(data, &retry); err != nil || retry.RetryAfter < 1 {
+				t.Errorf("Should get retry_after in the error data, got %s", data)
+			}
+
+		case resp.Error != nil:
+			t.Errorf("Should not limit call %d, got %+v", i+1, resp.Error)
+		}
+	}
+}
+
+func TestRPCAccountLimitForgedFrom(t *testing.T) {
+	victimKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Should be able to generate the victim key: %s", err)
+	}
+	attackerKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Should be able to generate the attacker key: %s", err)
+	}
+	victim := database.PublicKeyToAccountID(victimKey.PublicKey)
+
+	// Each account can submit one transaction.
+	app := newApp(t, victimKey, Handlers{AccountLimiter: ratelimit.New(0.0001, 1)})
+
+	// The attacker signs transactions that claim to be from the victim.
+	tx, err := database.NewTx(1, 1, victim, "0xbEE6ACE826eC3DE1B6349888B9151B92522F7F76", 1, 0, nil)
+	if err != nil {
+		t.Fatalf("Should be able to construct the transaction: %s", err)
+	}
+	forged, err := tx.Sign(attackerKey)
+	if err != nil {
+		t.Fatalf("Should be able to sign the transaction: %s", err)
+	}
+	params, _ := json.Marshal([]any{forged})
+
+	var calls []string
+	for i := 1; i <= 3; i++ {
+		calls = append(calls, fmt.Sprintf(`{"jsonrpc":"2.0","method":"ardan_sendTransaction","params":%s,"id":%d}`, params, i))
+	}
+	calls = append(calls, fmt.Sprintf(`{"jsonrpc":"2.0","method":"ardan_sendTransaction","params":%s,"id":4}`, signedTx(t, victimKey, 1)))
+
+	w := post(app, "["+strings.Join(calls, ",")+"]")
+
+	var resps []response
+	if err := json.NewDecoder(w.Body).Decode(&resps); err != nil {
+		t.Fatalf("Should be able to decode the responses: %s", err)
+	}
+
+	if len(resps) != 4 {
+		t.Fatalf("Should get 4 responses, got %d", len(resps))
+	}
+
+	for _, resp := range resps[:3] {
+		if resp.Error == nil || resp.Error.Code != codeServerError {
+			t.Errorf("Should reject the forged transaction for its signature, got %+v", resp.Error)
+		}
+	}
+
+	if resps[3].Error != nil {
+		t.Errorf("Should accept the victim's own transaction, got %+v", resps[3].Error)
+	}
+}
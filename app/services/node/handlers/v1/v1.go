@@ -7,8 +7,12 @@ import (
 
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/app/services/node/handlers/v1/private"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/app/services/node/handlers/v1/public"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/app/services/node/handlers/v1/rpc"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/web/v1/mid"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/bus"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/state"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/nameservice"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/ratelimit"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/web"
 	"go.uber.org/zap"
 )
@@ -20,6 +24,12 @@ type Config struct {
 	Log   *zap.SugaredLogger
 	NS    *nameservice.NameService
 	State *state.State
+	Bus   *bus.Bus
+
+	// Limiters for transaction submission by remote IP and sending account.
+	// A nil limiter doesn't limit anything.
+	IPLimiter      *ratelimit.Limiter
+	AccountLimiter *ratelimit.Limiter
 }
 
 // PublicRoutes binds all the version 1 public routes.
@@ -28,15 +38,42 @@ func PublicRoutes(app *web.App, cfg Config) {
 		Log:   cfg.Log,
 		NS:    cfg.NS,
 		State: cfg.State,
+		Bus:   cfg.Bus,
+
+		AccountLimiter: cfg.AccountLimiter,
 	}
 
+	// Transaction submission is limited by the remote IP here and by the
+	// account sending the transaction in the handler.
+	submitLimit := mid.RateLimit(cfg.IPLimiter)
+
+	app.Handle(http.MethodGet, version, "/openapi.json", pbl.OpenAPI)
+	app.Handle(http.MethodGet, version, "/events", pbl.Events)
 	app.Handle(http.MethodGet, version, "/genesis/list", pbl.Genesis)
 	app.Handle(http.MethodGet, version, "/accounts/list", pbl.Accounts)
 	app.Handle(http.MethodGet, version, "/accounts/list/:account", pbl.Accounts)
+	app.Handle(http.MethodGet, version, "/names/list", pbl.Names)
+	app.Handle(http.MethodGet, version, "/names", pbl.NameList)
+	app.Handle(http.MethodGet, version, "/names/lookup/:name", pbl.NameLookup)
+	app.Handle(http.MethodPost, version, "/signature/verify", pbl.VerifySignature)
 	app.Handle(http.MethodGet, version, "/tx/uncommitted/list", pbl.Mempool)
 	app.Handle(http.MethodGet, version, "/tx/uncommitted/list/:account", pbl.Mempool)
-	app.Handle(http.MethodPost, version, "/tx/submit", pbl.SubmitWalletTransaction)
-	app.Handle(http.MethodPost, version, "/tx/proof/:block/", pbl.SubmitWalletTransaction)
+	app.Handle(http.MethodGet, version, "/tx/status/:account/:nonce", pbl.TransactionStatus)
+	app.Handle(http.MethodGet, version, "/tx/hash/:hash", pbl.Transaction)
+	app.Handle(http.MethodPost, version, "/tx/submit", pbl.SubmitWalletTransaction, submitLimit)
+	app.Handle(http.MethodPost, version, "/tx/proof/:block/", pbl.SubmitWalletTransaction, submitLimit)
+
+	rpc := rpc.Handlers{
+		Log:   cfg.Log,
+		State: cfg.State,
+
+		IPLimiter:      cfg.IPLimiter,
+		AccountLimiter: cfg.AccountLimiter,
+	}
+
+	// The RPC methods can submit transactions, possibly many in a batch, so
+	// the limits are applied to each transaction inside the handler.
+	app.Handle(http.MethodPost, version, "/rpc", rpc.RPC)
 }
 
 // PrivateRoutes binds all the version 1 private routes.
@@ -0,0 +1,55 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	v1 "github.com/Kunmeer-SyedMohamedHyder/blockchain/app/services/node/handlers/v1"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/web"
+)
+
+// pathParam matches the parameters in a route path, like :account.
+var pathParam = regexp.MustCompile(`:([^/]+)`)
+
+func TestOpenAPIRoutes(t *testing.T) {
+	data, err := os.ReadFile("public/openapi.json")
+	if err != nil {
+		t.Fatalf("Should be able to read the OpenAPI document: %s", err)
+	}
+
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatalf("Should be able to decode the OpenAPI document: %s", err)
+	}
+
+	public := web.NewApp(nil, nil, nil)
+	v1.PublicRoutes(public, v1.Config{})
+
+	private := web.NewApp(nil, nil, nil)
+	v1.PrivateRoutes(private, v1.Config{})
+
+	registered := make(map[string]bool)
+	for _, route := range append(public.Routes(), private.Routes()...) {
+		path := pathParam.ReplaceAllString(route.Path, "{$1}")
+		method := strings.ToLower(route.Method)
+		registered[method+" "+path] = true
+
+		if _, exists := spec.Paths[path][method]; !exists {
+			t.Errorf("Route %s %s should be described in the OpenAPI document.", route.Method, path)
+		}
+	}
+
+	methods := map[string]bool{"get": true, "put": true, "post": true, "delete": true, "options": true, "head": true, "patch": true, "trace": true}
+	for path, operations := range spec.Paths {
+		for method := range operations {
+			if methods[method] && !registered[method+" "+path] {
+				t.Errorf("Operation %s %s in the OpenAPI document should be a registered route.", strings.ToUpper(method), path)
+			}
+		}
+	}
+}
@@ -12,13 +12,19 @@ import (
 	"time"
 
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/app/services/node/handlers"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/web/auth"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/web/metrics"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/bus"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/genesis"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/state"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/keystore"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/logger"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/nameservice"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/ratelimit"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/tracing"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/web"
 	"github.com/ardanlabs/conf/v3"
-	"github.com/ethereum/go-ethereum/crypto"
 	"go.uber.org/zap"
 )
 
@@ -64,13 +70,30 @@ func run(log *zap.SugaredLogger) error {
 		}
 		State struct {
 			Beneficiary    string   `conf:"default:miner1"`
+			Passphrase     string   `conf:"mask"` // Passphrase for an encrypted beneficiary key.
+			PassphraseFile string   // File holding the passphrase for an encrypted beneficiary key.
 			DBPath         string   `conf:"default:zblock/miner1/"`
 			SelectStrategy string   `conf:"default:Tip"`
 			OriginPeers    []string `conf:"default:0.0.0.0:9080"` //
 			Consensus      string   `conf:"default:POW"`          // Change to POA to run Proof of Authority
 		}
 		NameService struct {
-			Folder string `conf:"default:zblock/accounts/"`
+			Folder       string        `conf:"default:zblock/accounts/"`
+			PollInterval time.Duration `conf:"default:0s"` // How often the folder is reloaded, 0 to only reload on SIGHUP.
+		}
+		RateLimit struct {
+			IPRate       float64 `conf:"default:10"` // Transaction submissions per second allowed from a remote IP, 0 to disable.
+			IPBurst      int     `conf:"default:20"`
+			AccountRate  float64 `conf:"default:2"` // Transaction submissions per second allowed from an account, 0 to disable.
+			AccountBurst int     `conf:"default:10"`
+		}
+		NodeAuth struct {
+			Peers  []string      // Accounts of the nodes allowed to call the private API.
+			Window time.Duration `conf:"default:30s"` // How far a request timestamp can be from the current time.
+		}
+		Tracing struct {
+			Exporter string `conf:"default:none"` // Where spans are exported: file, stdout or none.
+			File     string `conf:"default:zblock/traces.jsonl"`
 		}
 	}{
 		Version: conf.Version{
@@ -113,6 +136,34 @@ func run(log *zap.SugaredLogger) error {
 
 	expvar.NewString("build").Set(build)
 
+	// =========================================================================
+	// Tracing Support
+
+	log.Infow("startup", "status", "initializing tracing support", "exporter", cfg.Tracing.Exporter)
+
+	var exporter tracing.Exporter
+	switch cfg.Tracing.Exporter {
+	case "file":
+		fe, closer, err := tracing.NewFileExporter(cfg.Tracing.File)
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+		exporter = fe
+
+	case "stdout":
+		exporter = tracing.NewWriterExporter(os.Stdout)
+
+	case "none":
+
+	default:
+		return fmt.Errorf("unknown tracing exporter %q", cfg.Tracing.Exporter)
+	}
+
+	tracer := tracing.NewTracer("node", exporter, func(err error) {
+		log.Errorw("tracing", "status", "exporting span", "ERROR", err)
+	})
+
 	// =========================================================================
 	// Name Service Support
 
@@ -128,23 +179,75 @@ func run(log *zap.SugaredLogger) error {
 		log.Infow("startup", "status", "nameservice", "name", name, "account", account)
 	}
 
+	// The names are reloaded when the node receives a SIGHUP, and on an
+	// interval when polling is configured, so new keys are picked up
+	// without a restart.
+	// The reloading stops when the node shuts down.
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	defer signal.Stop(reload)
+
+	var poll <-chan time.Time
+	if cfg.NameService.PollInterval > 0 {
+		ticker := time.NewTicker(cfg.NameService.PollInterval)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+
+	stopReload := make(chan struct{})
+	defer close(stopReload)
+
+	go func() {
+		for {
+			select {
+			case <-reload:
+			case <-poll:
+			case <-stopReload:
+				return
+			}
+
+			changed, err := ns.Reload()
+			if err != nil {
+				log.Errorw("nameservice", "status", "reload failed", "ERROR", err)
+				continue
+			}
+			if changed {
+				log.Infow("nameservice", "status", "reloaded", "names", len(ns.Names()))
+			}
+		}
+	}()
+
 	// =========================================================================
 	// Blockchain Support
 
 	// Need to load the private key file for the configured beneficiary so the
-	// account can get credited with fees and tips.
-	path := fmt.Sprintf("%s%s.ecdsa", cfg.NameService.Folder, cfg.State.Beneficiary)
-	privateKey, err := crypto.LoadECDSA(path)
+	// account can get credited with fees and tips. If the key is stored in an
+	// encrypted keystore, the passphrase comes from the configuration or is
+	// prompted for on the terminal.
+	passphrase := func() (string, error) {
+		switch {
+		case cfg.State.Passphrase != "":
+			return cfg.State.Passphrase, nil
+		case cfg.State.PassphraseFile != "":
+			return keystore.ReadPassphrase(cfg.State.PassphraseFile)
+		}
+		return keystore.PromptPassphrase(fmt.Sprintf("Passphrase for %s: ", cfg.State.Beneficiary), false)
+	}
+
+	privateKey, err := keystore.LoadKey(cfg.NameService.Folder, cfg.State.Beneficiary, passphrase)
 	if err != nil {
 		return fmt.Errorf("unable to load private key for node: %w", err)
 	}
 
-	ev := func(v string, args ...any) {
-
-		s := fmt.Sprintf(v, args...)
-		log.Infow(s, "traceid", "00000000-0000-0000-0000-000000000000")
-
-	}
+	// The bus delivers the blockchain events to the logger and the clients of
+	// the public event stream. Events raised while handling a request carry
+	// the trace id of the request.
+	evBus := bus.New(web.GetTraceID)
+	waitLog := bus.Log(evBus, func(traceID string, msg string) {
+		log.Infow(msg, "traceid", traceID)
+	})
+	defer waitLog()
+	defer evBus.Shutdown()
 
 	// Load the genesis file for blockchain settings and origin balances.
 	genesis, err := genesis.Load()
@@ -154,17 +257,31 @@ func run(log *zap.SugaredLogger) error {
 
 	// The state value represents the blockchain node and manages the blockchain
 	// database and provides an API for application support.
-	state, err := state.New(state.Config{
+	state, err := state.New(web.NewTraceContext(context.Background()), state.Config{
 		BeneficiaryID:  database.PublicKeyToAccountID(privateKey.PublicKey),
 		Genesis:        genesis,
 		SelectStrategy: cfg.State.SelectStrategy,
 
-		EvHandler: ev,
+		Bus: evBus,
 	})
 	if err != nil {
 		return err
 	}
-	defer state.Shutdown()
+
+	// Names registered on chain are used for the accounts and names the
+	// accounts folder doesn't know about.
+	ns.SetRegistry(state)
+
+	// Expose the chain level metrics along with the web metrics.
+	metrics.AddGauge("blockchain_mempool_size", "Number of transactions in the mempool.", func() float64 {
+		return float64(state.MempoolLength())
+	})
+	metrics.AddGauge("blockchain_accounts", "Number of accounts in the database.", func() float64 {
+		return float64(len(state.Accounts()))
+	})
+	metrics.AddGauge("blockchain_events_dropped", "Number of events dropped for subscribers that fell behind.", func() float64 {
+		return float64(evBus.Dropped())
+	})
 
 	// =========================================================================
 	// Start Debug Service
@@ -208,6 +325,11 @@ func run(log *zap.SugaredLogger) error {
 		Log:      log,
 		State:    state,
 		NS:       ns,
+		Bus:      evBus,
+		Tracer:   tracer,
+
+		IPLimiter:      ratelimit.New(cfg.RateLimit.IPRate, cfg.RateLimit.IPBurst),
+		AccountLimiter: ratelimit.New(cfg.RateLimit.AccountRate, cfg.RateLimit.AccountBurst),
 	})
 
 	// Construct a server to service the requests against the mux.
@@ -231,10 +353,24 @@ func run(log *zap.SugaredLogger) error {
 
 	log.Infow("startup", "status", "initializing V1 private API support")
 
+	// The private API is for node-to-node traffic, so requests must be signed
+	// by one of the configured peers. The node is always allowed to call
+	// itself.
+	peers := []database.AccountID{database.PublicKeyToAccountID(privateKey.PublicKey)}
+	for _, peer := range cfg.NodeAuth.Peers {
+		accountID, err := database.ToAccountID(peer)
+		if err != nil {
+			return fmt.Errorf("invalid peer account %q: %w", peer, err)
+		}
+		peers = append(peers, accountID)
+	}
+
 	// Construct the mux for the private API calls.
 	privateMux := handlers.PrivateMux(handlers.MuxConfig{
 		Shutdown: shutdown,
 		Log:      log,
+		Auth:     auth.New(peers, cfg.NodeAuth.Window),
+		Tracer:   tracer,
 	})
 
 	// Construct a server to service the requests against the mux.
@@ -259,12 +395,23 @@ func run(log *zap.SugaredLogger) error {
 	// Blocking main and waiting for shutdown.
 	select {
 	case err := <-serverErrors:
+		ctx := web.NewTraceContext(context.Background())
+		state.Shutdown(ctx)
+		state.ShutdownCompleted(ctx)
 		return fmt.Errorf("server error: %w", err)
 
 	case sig := <-shutdown:
 		log.Infow("shutdown", "status", "shutdown started", "signal", sig)
 		defer log.Infow("shutdown", "status", "shutdown complete", "signal", sig)
 
+		// Shutting the state down first lets the event stream clients know
+		// the node is going away, which ends the streams so they don't hold
+		// up the public API shutdown. The shutdown is only complete once
+		// both servers have stopped and no request can reach the state.
+		traceCtx := web.NewTraceContext(context.Background())
+		state.Shutdown(traceCtx)
+		defer state.ShutdownCompleted(traceCtx)
+
 		// Give outstanding requests a deadline for completion.
 		ctx, cancelPub := context.WithTimeout(context.Background(), cfg.Web.ShutdownTimeout)
 		defer cancelPub()
@@ -0,0 +1,142 @@
+// This program builds a genesis file from the accounts in the accounts folder.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/genesis"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/signature"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/nameservice"
+)
+
+var (
+	accountsFolder string
+	outFile        string
+	chainID        uint
+	transPerBlock  uint
+	difficulty     uint
+	miningReward   uint64
+	gasPrice       uint64
+	nameFee        uint64
+	balances       = balanceFlag{}
+)
+
+func init() {
+	flag.StringVar(&accountsFolder, "accounts", "zblock/accounts/", "folder with the account keys")
+	flag.StringVar(&outFile, "out", "zblock/genesis.json", "file to write the genesis information to")
+	flag.UintVar(&chainID, "chain", 1, "unique id for this running instance")
+	flag.UintVar(&transPerBlock, "trans", 10, "maximum number of transactions in a block")
+	flag.UintVar(&difficulty, "difficulty", 6, "how difficult it needs to be to solve the work problem")
+	flag.Uint64Var(&miningReward, "reward", 700, "reward for mining a block")
+	flag.Uint64Var(&gasPrice, "gas", 15, "fee paid for each transaction mined into a block")
+	flag.Uint64Var(&nameFee, "namefee", 100, "fee paid to register or transfer a name")
+	flag.Var(&balances, "balance", "origin balance for an account as name=amount or account=amount, can be repeated")
+}
+
+func main() {
+	flag.Parse()
+
+	if err := run(); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func run() error {
+	if chainID > 0xFFFF || transPerBlock > 0xFFFF || difficulty > 0xFFFF {
+		return errors.New("chain, trans and difficulty must fit in 16 bits")
+	}
+
+	// The account names come from the file names in the accounts folder.
+	ns, err := nameservice.New(accountsFolder)
+	if err != nil {
+		return fmt.Errorf("unable to load account name service: %w", err)
+	}
+
+	accounts := make(map[string]database.AccountID)
+	for accountID, name := range ns.Copy() {
+		accounts[name] = accountID
+	}
+
+	gen := genesis.Genesis{
+		Date:          time.Now().UTC().Truncate(time.Second),
+		ChainID:       uint16(chainID),
+		TransPerBlock: uint16(transPerBlock),
+		Difficulty:    uint16(difficulty),
+		MiningReward:  miningReward,
+		GasPrice:      gasPrice,
+		NameFee:       nameFee,
+		Balances:      make(map[string]uint64),
+	}
+
+	// Accounts without a key file, like a multisig account, can be
+	// provided directly.
+	for name, balance := range balances {
+		if accountID, err := database.ToAccountID(name); err == nil {
+			accounts[name] = accountID
+		}
+
+		accountID, exists := accounts[name]
+		if !exists {
+			return fmt.Errorf("account %q not found in %s", name, accountsFolder)
+		}
+		gen.Balances[string(accountID)] = balance
+	}
+
+	if err := genesis.Save(outFile, gen); err != nil {
+		return fmt.Errorf("unable to save genesis: %w", err)
+	}
+
+	names := make([]string, 0, len(balances))
+	for name := range balances {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Printf("Genesis: %s\n", outFile)
+	for _, name := range names {
+		fmt.Printf("  %s: %s: %d\n", name, accounts[name], balances[name])
+	}
+	fmt.Printf("Hash: %s\n", signature.Hash(gen))
+
+	return nil
+}
+
+// =============================================================================
+
+// balanceFlag collects the name=amount pairs provided on the command line.
+type balanceFlag map[string]uint64
+
+// String implements the flag.Value interface.
+func (bf balanceFlag) String() string {
+	pairs := make([]string, 0, len(bf))
+	for name, balance := range bf {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", name, balance))
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+// Set implements the flag.Value interface.
+func (bf balanceFlag) Set(value string) error {
+	name, amount, found := strings.Cut(value, "=")
+	if !found || name == "" {
+		return fmt.Errorf("balance %q must be in the form name=amount", value)
+	}
+
+	balance, err := strconv.ParseUint(amount, 10, 64)
+	if err != nil {
+		return fmt.Errorf("balance %q: %w", value, err)
+	}
+	bf[name] = balance
+
+	return nil
+}
@@ -1,4 +1,6 @@
-// This program takes the structured log output and makes it readable.
+// This program takes the structured log output and makes it readable. The
+// logs are read from standard input or from the files provided as arguments
+// and can be filtered by service, level, trace id, message and time.
 package main
 
 import (
@@ -6,75 +8,439 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
-var service string
+const zeroTraceID = "00000000-0000-0000-0000-000000000000"
+
+var (
+	service string
+	level   string
+	traceID string
+	grep    string
+	since   string
+	until   string
+	color   string
+	group   bool
+	follow  bool
+)
 
 func init() {
 	flag.StringVar(&service, "service", "", "filter which service to see")
+	flag.StringVar(&level, "level", "", "filter out logs below this level: debug, info, warn, error")
+	flag.StringVar(&traceID, "traceid", "", "filter which trace to see, uuid or traceparent form, a prefix is enough")
+	flag.StringVar(&grep, "grep", "", "filter logs whose message contains this text")
+	flag.StringVar(&since, "since", "", "filter out logs before this time, a timestamp or a duration like 10m")
+	flag.StringVar(&until, "until", "", "filter out logs after this time, a timestamp or a duration like 10m")
+	flag.StringVar(&color, "color", "auto", "color the levels: auto, always or never")
+	flag.BoolVar(&group, "group", false, "print the logs grouped by trace once all the input is read")
+	flag.BoolVar(&follow, "follow", false, "keep reading the files as they grow")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: logfmt [flags] [file ...]\n\nReads standard input when no files are provided.\n\n")
+		flag.PrintDefaults()
+	}
 }
 
 func main() {
 	flag.Parse()
-	var b strings.Builder
 
-	// Scan standard input for log data per line.
-	scanner := bufio.NewScanner(os.Stdin)
-	for scanner.Scan() {
-		s := scanner.Text()
+	f, err := newFilter()
+	if err != nil {
+		log.Fatal(err)
+	}
 
-		// Convert the JSON to a map for processing.
-		m := make(map[string]any)
-		err := json.Unmarshal([]byte(s), &m)
-		if err != nil {
-			if service == "" {
-				fmt.Println(s)
-			}
+	if group && follow {
+		log.Fatal("-group waits for the end of the input so it can't be used with -follow")
+	}
+
+	useColor, err := colorOutput()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	lines := make(chan string)
+	go read(flag.Args(), follow, lines)
+
+	var groups traceGroups
+	for s := range lines {
+		e, ok := parse(s)
+		if !f.match(e, ok) {
 			continue
 		}
 
-		// If a service filter was provided, check.
-		if service != "" && m["service"] != service {
+		out := s
+		if ok {
+			out = format(e, useColor)
+		}
+
+		if group {
+			groups.add(e.traceID, out)
 			continue
 		}
+		fmt.Println(out)
+	}
 
-		// I like always having a traceid present in the logs.
-		traceID := "00000000-0000-0000-0000-000000000000"
-		if v, ok := m["traceid"]; ok {
-			traceID = fmt.Sprintf("%v", v)
+	if group {
+		groups.print()
+	}
+}
+
+// =============================================================================
+
+// entry represents a log line that was decoded.
+type entry struct {
+	m       map[string]any
+	ts      time.Time
+	level   string
+	traceID string
+	msg     string
+}
+
+// parse decodes the log line. It returns false when the line isn't a JSON
+// log, like the banner printed at startup.
+func parse(s string) (entry, bool) {
+	m := make(map[string]any)
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		return entry{traceID: zeroTraceID}, false
+	}
+
+	// I like always having a traceid present in the logs.
+	e := entry{
+		m:       m,
+		traceID: zeroTraceID,
+	}
+	if v, ok := m["traceid"]; ok {
+		e.traceID = fmt.Sprintf("%v", v)
+	}
+	if v, ok := m["level"].(string); ok {
+		e.level = v
+	}
+	if v, ok := m["msg"].(string); ok {
+		e.msg = v
+	}
+	if v, ok := m["ts"].(string); ok {
+		e.ts, _ = parseTimestamp(v)
+	}
+
+	return e, true
+}
+
+// format builds the readable form of the log.
+func format(e entry, useColor bool) string {
+	var b strings.Builder
+
+	lvl := fmt.Sprintf("%v", e.m["level"])
+	if useColor {
+		lvl = colorize(e.level, lvl)
+	}
+
+	// Build out the know portions of the log in the order
+	// I want them in.
+	b.WriteString(fmt.Sprintf("%s: %s: %s: %s: %s: %s: ",
+		e.m["service"],
+		e.m["ts"],
+		lvl,
+		e.traceID,
+		e.m["caller"],
+		e.m["msg"],
+	))
+
+	// Add the rest of the keys ignoring the ones we already added for the
+	// log. The keys are sorted so the same log always reads the same.
+	keys := make([]string, 0, len(e.m))
+	for k := range e.m {
+		switch k {
+		case "service", "ts", "level", "traceid", "caller", "msg":
+			continue
 		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		b.WriteString(fmt.Sprintf("%s[%v]: ", k, e.m[k]))
+	}
+
+	// Return the new log format, removing the last :
+	out := b.String()
+	return out[:len(out)-2]
+}
+
+// =============================================================================
+
+// levels ranks the levels zap writes from least to most severe.
+var levels = map[string]int{
+	"debug":  0,
+	"info":   1,
+	"warn":   2,
+	"error":  3,
+	"dpanic": 4,
+	"panic":  5,
+	"fatal":  6,
+}
+
+// filter decides which logs are printed.
+type filter struct {
+	service  string
+	minLevel int
+	traceID  string
+	grep     string
+	since    time.Time
+	until    time.Time
+}
+
+// newFilter constructs the filter from the flags.
+func newFilter() (filter, error) {
+	f := filter{
+		service:  service,
+		minLevel: -1,
+		traceID:  normalizeTraceID(traceID),
+		grep:     grep,
+	}
 
-		// Build out the know portions of the log in the order
-		// I want them in.
-		b.Reset()
-		b.WriteString(fmt.Sprintf("%s: %s: %s: %s: %s: %s: ",
-			m["service"],
-			m["ts"],
-			m["level"],
-			traceID,
-			m["caller"],
-			m["msg"],
-		))
-
-		// Add the rest of the keys ignoring the ones we already
-		// added for the log.
-		for k, v := range m {
-			switch k {
-			case "service", "ts", "level", "traceid", "caller", "msg":
-				continue
+	if level != "" {
+		lvl, exists := levels[strings.ToLower(level)]
+		if !exists {
+			return filter{}, fmt.Errorf("unknown level %q", level)
+		}
+		f.minLevel = lvl
+	}
+
+	var err error
+	if f.since, err = parseTime(since); err != nil {
+		return filter{}, fmt.Errorf("parsing since: %w", err)
+	}
+	if f.until, err = parseTime(until); err != nil {
+		return filter{}, fmt.Errorf("parsing until: %w", err)
+	}
+
+	return f, nil
+}
+
+// active reports if any filter was provided.
+func (f filter) active() bool {
+	return f.service != "" || f.minLevel >= 0 || f.traceID != "" || f.grep != "" || !f.since.IsZero() || !f.until.IsZero()
+}
+
+// match reports if the log should be printed. Lines that aren't JSON logs
+// are only printed when there are no filters.
+func (f filter) match(e entry, ok bool) bool {
+	if !ok {
+		return !f.active()
+	}
+
+	if f.service != "" && e.m["service"] != f.service {
+		return false
+	}
+
+	if f.minLevel >= 0 {
+		lvl, exists := levels[e.level]
+		if exists && lvl < f.minLevel {
+			return false
+		}
+	}
+
+	if f.traceID != "" && !strings.HasPrefix(normalizeTraceID(e.traceID), f.traceID) {
+		return false
+	}
+
+	if f.grep != "" && !strings.Contains(e.msg, f.grep) {
+		return false
+	}
+
+	if !f.since.IsZero() && e.ts.Before(f.since) {
+		return false
+	}
+
+	if !f.until.IsZero() && e.ts.After(f.until) {
+		return false
+	}
+
+	return true
+}
+
+// normalizeTraceID drops the dashes so a trace id in the uuid form of the
+// logs matches the same id taken from a traceparent header.
+func normalizeTraceID(id string) string {
+	return strings.ToLower(strings.ReplaceAll(id, "-", ""))
+}
+
+// parseTime parses the time as a timestamp or as a duration before now.
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+
+	return parseTimestamp(s)
+}
+
+// zapTimeLayout is the ISO8601 layout zap writes timestamps in. Unlike
+// RFC3339, the offset has no colon, like +0530.
+const zapTimeLayout = "2006-01-02T15:04:05.000Z0700"
+
+// parseTimestamp parses the time as zap writes it, falling back to RFC3339.
+func parseTimestamp(s string) (time.Time, error) {
+	if t, err := time.Parse(zapTimeLayout, s); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+// =============================================================================
+
+// colorOutput decides if the levels are colored.
+func colorOutput() (bool, error) {
+	switch color {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto":
+		fi, err := os.Stdout.Stat()
+		if err != nil {
+			return false, nil
+		}
+		return fi.Mode()&os.ModeCharDevice != 0, nil
+	}
+
+	return false, fmt.Errorf("unknown color mode %q", color)
+}
+
+// colorize wraps the text in the terminal color for the level.
+func colorize(level string, text string) string {
+	var code string
+	switch level {
+	case "debug":
+		code = "90"
+	case "info":
+		code = "32"
+	case "warn":
+		code = "33"
+	default:
+		code = "31"
+	}
+
+	return "\033[" + code + "m" + text + "\033[0m"
+}
+
+// =============================================================================
+
+// traceGroups collects the logs by trace in the order each trace was first
+// seen.
+type traceGroups struct {
+	order []string
+	lines map[string][]string
+}
+
+// add records the log for the trace.
+func (tg *traceGroups) add(traceID string, line string) {
+	if tg.lines == nil {
+		tg.lines = make(map[string][]string)
+	}
+
+	if _, exists := tg.lines[traceID]; !exists {
+		tg.order = append(tg.order, traceID)
+	}
+	tg.lines[traceID] = append(tg.lines[traceID], line)
+}
+
+// print writes each trace with its logs.
+func (tg *traceGroups) print() {
+	for _, id := range tg.order {
+		fmt.Printf("=== trace %s (%d)\n", id, len(tg.lines[id]))
+		for _, line := range tg.lines[id] {
+			fmt.Println(line)
+		}
+	}
+}
+
+// =============================================================================
+
+// read sends every line of the files, or standard input when there are no
+// files, and closes the channel when the input is done. When following, the
+// files are read at the same time and never end.
+func read(files []string, follow bool, lines chan<- string) {
+	defer close(lines)
+
+	if len(files) == 0 {
+		scan(os.Stdin, lines)
+		return
+	}
+
+	if !follow {
+		for _, file := range files {
+			if err := readFile(file, false, lines); err != nil {
+				log.Println(err)
+			}
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(files))
+	for _, file := range files {
+		go func(file string) {
+			defer wg.Done()
+			if err := readFile(file, true, lines); err != nil {
+				log.Println(err)
 			}
+		}(file)
+	}
+	wg.Wait()
+}
+
+// readFile sends every line of the file. When following, it waits for more
+// lines at the end of the file instead of returning.
+func readFile(file string, follow bool, lines chan<- string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if !follow {
+		scan(f, lines)
+		return nil
+	}
+
+	r := bufio.NewReader(f)
+	var partial string
+	for {
+		s, err := r.ReadString('\n')
+		partial += s
 
-			// It's nice to see the key[value] in this format
-			// especially since map ordering is random.
-			b.WriteString(fmt.Sprintf("%s[%v]: ", k, v))
+		switch {
+		case err == io.EOF:
+			time.Sleep(250 * time.Millisecond)
+		case err != nil:
+			return err
+		default:
+			lines <- strings.TrimRight(partial, "\r\n")
+			partial = ""
 		}
+	}
+}
 
-		// Write the new log format, removing the last :
-		out := b.String()
-		fmt.Println(out[:len(out)-2])
+// scan sends every line of the reader.
+func scan(r io.Reader, lines chan<- string) {
+	const maxLine = 1024 * 1024
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLine)
+	for scanner.Scan() {
+		lines <- scanner.Text()
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	exp := time.Date(2026, 10, 19, 4, 30, 0, 0, time.UTC)
+
+	tt := []struct {
+		name  string
+		value string
+		exp   time.Time
+		fails bool
+	}{
+		{name: "empty", value: ""},
+		{name: "zap utc", value: "2026-10-19T04:30:00.000Z", exp: exp},
+		{name: "zap offset", value: "2026-10-19T10:00:00.000+0530", exp: exp},
+		{name: "zap negative offset", value: "2026-10-18T23:30:00.000-0500", exp: exp},
+		{name: "rfc3339", value: "2026-10-19T10:00:00+05:30", exp: exp},
+		{name: "rfc3339 nano", value: "2026-10-19T04:30:00.000000001Z", exp: exp.Add(time.Nanosecond)},
+		{name: "date only", value: "2026-10-19", fails: true},
+		{name: "garbage", value: "yesterday", fails: true},
+	}
+
+	for _, tst := range tt {
+		t.Run(tst.name, func(t *testing.T) {
+			got, err := parseTime(tst.value)
+			if tst.fails {
+				if err == nil {
+					t.Errorf("Should reject %q", tst.value)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Should parse %q: %s", tst.value, err)
+			}
+			if !got.Equal(tst.exp) {
+				t.Errorf("Should get %s, got %s", tst.exp, got)
+			}
+		})
+	}
+
+	t.Run("duration", func(t *testing.T) {
+		got, err := parseTime("10m")
+		if err != nil {
+			t.Fatalf("Should parse the duration: %s", err)
+		}
+
+		if d := time.Since(got); d < 10*time.Minute || d > 11*time.Minute {
+			t.Errorf("Should get a time 10 minutes ago, got %s ago", d)
+		}
+	})
+}
+
+func TestFilterMatch(t *testing.T) {
+	const log = `{"level":"warn","ts":"2026-10-19T10:00:00.000+0530","service":"NODE","traceid":"4bf92f35-77b3-4da6-a3ce-929d0e0e4736","msg":"mempool: rejected"}`
+
+	at := time.Date(2026, 10, 19, 4, 30, 0, 0, time.UTC)
+
+	tt := []struct {
+		name   string
+		filter filter
+		line   string
+		exp    bool
+	}{
+		{name: "no filter", filter: filter{minLevel: -1}, line: log, exp: true},
+		{name: "service", filter: filter{minLevel: -1, service: "NODE"}, line: log, exp: true},
+		{name: "other service", filter: filter{minLevel: -1, service: "WALLET"}, line: log},
+		{name: "level below", filter: filter{minLevel: levels["info"]}, line: log, exp: true},
+		{name: "level same", filter: filter{minLevel: levels["warn"]}, line: log, exp: true},
+		{name: "level above", filter: filter{minLevel: levels["error"]}, line: log},
+		{name: "trace id prefix", filter: filter{minLevel: -1, traceID: normalizeTraceID("4bf92f35-77b3")}, line: log, exp: true},
+		{name: "traceparent trace id", filter: filter{minLevel: -1, traceID: "4bf92f3577b34da6a3ce929d0e0e4736"}, line: log, exp: true},
+		{name: "other trace id", filter: filter{minLevel: -1, traceID: "00f067aa"}, line: log},
+		{name: "grep", filter: filter{minLevel: -1, grep: "rejected"}, line: log, exp: true},
+		{name: "grep misses", filter: filter{minLevel: -1, grep: "accepted"}, line: log},
+		{name: "since before", filter: filter{minLevel: -1, since: at.Add(-time.Second)}, line: log, exp: true},
+		{name: "since after", filter: filter{minLevel: -1, since: at.Add(time.Second)}, line: log},
+		{name: "until after", filter: filter{minLevel: -1, until: at.Add(time.Second)}, line: log, exp: true},
+		{name: "until before", filter: filter{minLevel: -1, until: at.Add(-time.Second)}, line: log},
+		{name: "banner with no filter", filter: filter{minLevel: -1}, line: "starting node", exp: true},
+		{name: "banner with a filter", filter: filter{minLevel: -1, service: "NODE"}, line: "starting node"},
+	}
+
+	for _, tst := range tt {
+		t.Run(tst.name, func(t *testing.T) {
+			e, ok := parse(tst.line)
+			if got := tst.filter.match(e, ok); got != tst.exp {
+				t.Errorf("Should get match %v, got %v", tst.exp, got)
+			}
+		})
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"log"
 
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/spf13/cobra"
 )
 
@@ -20,7 +19,7 @@ func init() {
 }
 
 func accountRun(cmd *cobra.Command, args []string) {
-	privateKey, err := crypto.LoadECDSA(getPrivateKeyPath())
+	privateKey, err := loadPrivateKey()
 	if err != nil {
 		log.Fatal(err)
 	}
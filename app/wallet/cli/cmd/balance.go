@@ -7,7 +7,6 @@ import (
 	"net/http"
 
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/spf13/cobra"
 )
 
@@ -25,7 +24,7 @@ func init() {
 }
 
 func balanceRun(cmd *cobra.Command, args []string) {
-	privateKey, err := crypto.LoadECDSA(getPrivateKeyPath())
+	privateKey, err := loadPrivateKey()
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var broadcastCmd = &cobra.Command{
+	Use:   "broadcast [file]",
+	Short: "Send a transaction signed with the sign command",
+	Args:  cobra.MaximumNArgs(1),
+	Run:   broadcastRun,
+}
+
+func init() {
+	rootCmd.AddCommand(broadcastCmd)
+	broadcastCmd.Flags().StringVarP(&url, "url", "u", "http://localhost:8080", "Url of the node.")
+}
+
+func broadcastRun(cmd *cobra.Command, args []string) {
+	signedTx, err := readSignedTx(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := submitTx(signedTx); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/genesis"
+)
+
+// mempoolTx represents the fields of an uncommitted transaction the wallet
+// needs from the node.
+type mempoolTx struct {
+	FromAccount database.AccountID `json:"from"`
+	To          database.AccountID `json:"to"`
+	Nonce       uint64             `json:"nonce"`
+}
+
+// queryGenesis retrieves the genesis information from the node.
+func queryGenesis() (genesis.Genesis, error) {
+	var gen genesis.Genesis
+	if err := get(fmt.Sprintf("%s/v1/genesis/list", url), &gen); err != nil {
+		return genesis.Genesis{}, err
+	}
+
+	return gen, nil
+}
+
+// queryNextNonce calculates the next nonce for the account from the committed
+// account nonce plus the transactions the account has pending in the mempool.
+func queryNextNonce(accountID database.AccountID) (uint64, error) {
+
+	// An account the node doesn't know about yet has a nonce of zero.
+	var nonce uint64
+	var accounts map[database.AccountID]database.Account
+	switch err := get(fmt.Sprintf("%s/v1/accounts/list/%s", url, accountID), &accounts); {
+	case err == nil:
+		nonce = accounts[accountID].Nonce
+	case !errors.Is(err, errNotFound):
+		return 0, err
+	}
+
+	// Count the transactions the account sent that are still pending, one
+	// page at a time.
+	var cursor string
+	for {
+		var pg struct {
+			Items      []mempoolTx `json:"items"`
+			NextCursor string      `json:"next_cursor"`
+		}
+		endpoint := fmt.Sprintf("%s/v1/tx/uncommitted/list/%s?direction=from&limit=1000&cursor=%s", url, accountID, cursor)
+		if err := get(endpoint, &pg); err != nil {
+			return 0, err
+		}
+
+		nonce += uint64(len(pg.Items))
+
+		if pg.NextCursor == "" {
+			break
+		}
+		cursor = pg.NextCursor
+	}
+
+	return nonce + 1, nil
+}
+
+// errNotFound is returned by get when the node doesn't have the resource.
+var errNotFound = errors.New("not found")
+
+// get performs a GET call against the node and decodes the response.
+func get(endpoint string, val any) error {
+	resp, err := http.Get(endpoint)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var er struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&er)
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("%s: %w: %s", endpoint, errNotFound, er.Error)
+		}
+		return fmt.Errorf("%s: %d: %s", endpoint, resp.StatusCode, er.Error)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(val)
+}
+
+// txStatus represents the status of a transaction as reported by the node.
+type txStatus struct {
+	Status string `json:"status"`
+	Tx     *struct {
+		FromName string `json:"from_name"`
+		ToName   string `json:"to_name"`
+		Value    uint64 `json:"value"`
+		Tip      uint64 `json:"tip"`
+	} `json:"tx"`
+}
+
+// queryTxStatus retrieves the status of the account's transaction with the
+// specified nonce.
+func queryTxStatus(accountID database.AccountID, nonce uint64) (txStatus, error) {
+	var status txStatus
+	if err := get(fmt.Sprintf("%s/v1/tx/status/%s/%d", url, accountID, nonce), &status); err != nil {
+		return txStatus{}, err
+	}
+
+	return status, nil
+}
+
+// queryNames retrieves the registry of account names from the node.
+func queryNames() (map[string]database.AccountID, error) {
+	var names map[string]database.AccountID
+	if err := get(fmt.Sprintf("%s/v1/names/list", url), &names); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
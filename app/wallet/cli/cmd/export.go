@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Decrypt a keystore file into an .ecdsa key file",
+	Run:   exportRun,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+}
+
+func exportRun(cmd *cobra.Command, args []string) {
+	passphrase, err := getPassphrase(false)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	privateKey, err := keystore.Load(getPrivateKeyPath(keystore.ExtKeystore), passphrase)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	path := getPrivateKeyPath(keystore.ExtECDSA)
+	if err := crypto.SaveECDSA(path, privateKey); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(path)
+}
@@ -3,10 +3,13 @@ package cmd
 import (
 	"log"
 
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/keystore"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/spf13/cobra"
 )
 
+var encrypt bool
+
 var generateCmd = &cobra.Command{
 	Use:   "generate",
 	Short: "Generate new key pair",
@@ -15,6 +18,7 @@ var generateCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(generateCmd)
+	generateCmd.Flags().BoolVarP(&encrypt, "encrypt", "e", false, "Store the key in an encrypted keystore file.")
 }
 
 func generateRun(cmd *cobra.Command, args []string) {
@@ -23,7 +27,19 @@ func generateRun(cmd *cobra.Command, args []string) {
 		log.Fatal(err)
 	}
 
-	if err := crypto.SaveECDSA(getPrivateKeyPath(), privateKey); err != nil {
+	if !encrypt {
+		if err := crypto.SaveECDSA(getPrivateKeyPath(keystore.ExtECDSA), privateKey); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	passphrase, err := getPassphrase(true)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := keystore.Store(getPrivateKeyPath(keystore.ExtKeystore), privateKey, passphrase); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+var removePlain bool
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Encrypt an .ecdsa key file into a keystore file",
+	Run:   importRun,
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.Flags().BoolVarP(&removePlain, "remove", "r", false, "Remove the .ecdsa key file once the keystore file is written.")
+}
+
+func importRun(cmd *cobra.Command, args []string) {
+	path := getPrivateKeyPath(keystore.ExtECDSA)
+	privateKey, err := crypto.LoadECDSA(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	passphrase, err := getPassphrase(true)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	keystorePath := getPrivateKeyPath(keystore.ExtKeystore)
+	if err := keystore.Store(keystorePath, privateKey, passphrase); err != nil {
+		log.Fatal(err)
+	}
+
+	if removePlain {
+		if err := os.Remove(path); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	fmt.Println(keystorePath)
+}
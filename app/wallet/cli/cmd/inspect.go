@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/signature"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+)
+
+var inspectCmd = &cobra.Command{
+	Use:   "inspect [file]",
+	Short: "Show a signed transaction and who signed it",
+	Args:  cobra.MaximumNArgs(1),
+	Run:   inspectRun,
+}
+
+func init() {
+	rootCmd.AddCommand(inspectCmd)
+}
+
+func inspectRun(cmd *cobra.Command, args []string) {
+	signedTx, err := readSignedTx(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Chain ID : %d\n", signedTx.ChainID)
+	fmt.Printf("Nonce    : %d\n", signedTx.Nonce)
+	fmt.Printf("From     : %s\n", signedTx.FromID)
+	fmt.Printf("To       : %s\n", signedTx.ToID)
+	fmt.Printf("Value    : %d\n", signedTx.Value)
+	fmt.Printf("Tip      : %d\n", signedTx.Tip)
+	fmt.Printf("Data     : %s\n", hexutil.Encode(signedTx.Data))
+	fmt.Printf("Signature: %s\n", signedTx.SignatureString())
+
+	if signedTx.Approvals != nil {
+		inspectApprovals(signedTx)
+		return
+	}
+
+	if signedTx.V == nil || signedTx.R == nil || signedTx.S == nil {
+		log.Fatal("transaction is not signed")
+	}
+
+	if err := signature.VerifySignature(signedTx.V, signedTx.R, signedTx.S); err != nil {
+		log.Fatal(err)
+	}
+
+	signer, err := signature.ExtractAddress(signedTx.Tx, signedTx.V, signedTx.R, signedTx.S)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Signer   : %s\n", signer)
+
+	if signer != string(signedTx.FromID) {
+		log.Fatal("signer doesn't match the from account, the node will reject this transaction")
+	}
+}
+
+// inspectApprovals shows the owners of the multisig account who have signed
+// the transaction.
+func inspectApprovals(signedTx database.SignedTx) {
+	approvers, err := signedTx.Approvers()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Owners   : %s\n", signedTx.Approvals.Owners)
+	fmt.Printf("Approvers: %s\n", approvers)
+	fmt.Printf("Approved : %d of %d\n", len(approvers), signedTx.Approvals.Threshold)
+
+	if signedTx.FromID != signedTx.Approvals.AccountID() {
+		log.Fatal("from account doesn't match the multisig account, the node will reject this transaction")
+	}
+}
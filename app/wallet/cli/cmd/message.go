@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/signature"
+	"github.com/spf13/cobra"
+)
+
+var (
+	message string
+	sig     string
+)
+
+var signMessageCmd = &cobra.Command{
+	Use:   "sign-message",
+	Short: "Sign a message to prove ownership of the account",
+	Run:   signMessageRun,
+}
+
+var verifyMessageCmd = &cobra.Command{
+	Use:   "verify-message",
+	Short: "Print the account that signed a message",
+	Run:   verifyMessageRun,
+}
+
+func init() {
+	rootCmd.AddCommand(signMessageCmd)
+	rootCmd.AddCommand(verifyMessageCmd)
+	signMessageCmd.Flags().StringVarP(&message, "message", "m", "", "Message to sign.")
+	verifyMessageCmd.Flags().StringVarP(&message, "message", "m", "", "Message that was signed.")
+	verifyMessageCmd.Flags().StringVarP(&sig, "signature", "s", "", "Hex encoded signature of the message.")
+	verifyMessageCmd.Flags().StringVarP(&from, "from", "f", "", "Account or name expected to have signed the message.")
+	verifyMessageCmd.MarkFlagRequired("signature")
+}
+
+func signMessageRun(cmd *cobra.Command, args []string) {
+	privateKey, err := loadPrivateKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	v, r, s, err := signature.Sign(message, privateKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(signature.SignatureString(v, r, s))
+}
+
+func verifyMessageRun(cmd *cobra.Command, args []string) {
+	signer, err := signature.VerifyMessage(message, sig)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(signer)
+
+	if from == "" {
+		return
+	}
+
+	accountID, _, err := resolveAccount(from)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// The account may have been typed without the checksum casing.
+	if !strings.EqualFold(string(accountID), signer) {
+		log.Fatalf("message was not signed by %s", accountID)
+	}
+}
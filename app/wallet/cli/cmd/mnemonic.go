@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/hdwallet"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/keystore"
+	"github.com/spf13/cobra"
+)
+
+var (
+	words string
+	count uint32
+)
+
+var mnemonicCmd = &cobra.Command{
+	Use:   "mnemonic",
+	Short: "Manage the mnemonic used to derive accounts",
+}
+
+var mnemonicNewCmd = &cobra.Command{
+	Use:   "new",
+	Short: "Generate a new mnemonic for the account",
+	Run:   mnemonicNewRun,
+}
+
+var mnemonicRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore the account's mnemonic from a backup",
+	Run:   mnemonicRestoreRun,
+}
+
+var mnemonicListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the accounts derived from the account's mnemonic",
+	Run:   mnemonicListRun,
+}
+
+func init() {
+	rootCmd.AddCommand(mnemonicCmd)
+	mnemonicCmd.AddCommand(mnemonicNewCmd)
+	mnemonicCmd.AddCommand(mnemonicRestoreCmd)
+	mnemonicCmd.AddCommand(mnemonicListCmd)
+	mnemonicRestoreCmd.Flags().StringVarP(&words, "words", "w", "", "The space separated words of the mnemonic.")
+	mnemonicListCmd.Flags().Uint32VarP(&count, "count", "n", 10, "Number of accounts to list.")
+}
+
+func mnemonicNewRun(cmd *cobra.Command, args []string) {
+	mnemonic, err := hdwallet.NewMnemonic()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := saveMnemonic(mnemonic); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("Write down these words and keep them safe, they restore every derived account:")
+	fmt.Println(mnemonic)
+}
+
+func mnemonicRestoreRun(cmd *cobra.Command, args []string) {
+	mnemonic := strings.Join(strings.Fields(words), " ")
+	if err := hdwallet.ValidateMnemonic(mnemonic); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := saveMnemonic(mnemonic); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(getPrivateKeyPath(extMnemonic))
+}
+
+func mnemonicListRun(cmd *cobra.Command, args []string) {
+	seed, err := loadSeed()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for i := uint32(0); i < count; i++ {
+		privateKey, err := hdwallet.Derive(seed, i)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("%d: %s\n", i, database.PublicKeyToAccountID(privateKey.PublicKey))
+	}
+}
+
+// saveMnemonic encrypts the mnemonic with the account's passphrase and writes
+// the mnemonic file, refusing to replace an existing one since that would
+// lose access to the derived accounts.
+func saveMnemonic(mnemonic string) error {
+	path := getPrivateKeyPath(extMnemonic)
+	if _, err := os.Stat(path); err == nil {
+		return errors.New("mnemonic already exists for account: " + path)
+	}
+
+	passphrase, err := getPassphrase(true)
+	if err != nil {
+		return err
+	}
+
+	content, err := keystore.EncryptData([]byte(mnemonic), passphrase)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0600)
+}
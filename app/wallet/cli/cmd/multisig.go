@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/spf13/cobra"
+)
+
+var (
+	owners       []string
+	threshold    uint16
+	multiSigFile string
+)
+
+var multiSigCmd = &cobra.Command{
+	Use:   "multisig",
+	Short: "Manage transactions from multisig accounts",
+}
+
+var multiSigCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Define a multisig account from a set of owners and a threshold",
+	Run:   multiSigCreateRun,
+}
+
+var multiSigProposeCmd = &cobra.Command{
+	Use:   "propose",
+	Short: "Create a transaction from a multisig account signed by the account",
+	Run:   multiSigProposeRun,
+}
+
+var multiSigApproveCmd = &cobra.Command{
+	Use:   "approve [file]",
+	Short: "Add the account's signature to a multisig transaction",
+	Args:  cobra.MaximumNArgs(1),
+	Run:   multiSigApproveRun,
+}
+
+var multiSigSubmitCmd = &cobra.Command{
+	Use:   "submit [file]",
+	Short: "Send a multisig transaction once enough owners signed it",
+	Args:  cobra.MaximumNArgs(1),
+	Run:   multiSigSubmitRun,
+}
+
+func init() {
+	rootCmd.AddCommand(multiSigCmd)
+	multiSigCmd.AddCommand(multiSigCreateCmd)
+	multiSigCmd.AddCommand(multiSigProposeCmd)
+	multiSigCmd.AddCommand(multiSigApproveCmd)
+	multiSigCmd.AddCommand(multiSigSubmitCmd)
+
+	multiSigCreateCmd.Flags().StringSliceVar(&owners, "owners", nil, "Comma separated owner accounts or names.")
+	multiSigCreateCmd.Flags().Uint16Var(&threshold, "threshold", 1, "Number of owners required to sign a transaction.")
+	multiSigCreateCmd.Flags().StringVarP(&out, "out", "o", "", "File to write the multisig definition to, stdout by default.")
+
+	multiSigProposeCmd.Flags().StringVarP(&url, "url", "u", "http://localhost:8080", "Url of the node.")
+	multiSigProposeCmd.Flags().StringVarP(&multiSigFile, "multisig", "m", "", "File with the multisig definition.")
+	multiSigProposeCmd.Flags().StringVarP(&out, "out", "o", "", "File to write the transaction to, stdout by default.")
+	multiSigProposeCmd.MarkFlagRequired("multisig")
+	addTxFlags(multiSigProposeCmd)
+
+	multiSigApproveCmd.Flags().StringVarP(&out, "out", "o", "", "File to write the transaction to, defaults to the input file or stdout.")
+
+	multiSigSubmitCmd.Flags().StringVarP(&url, "url", "u", "http://localhost:8080", "Url of the node.")
+}
+
+func multiSigCreateRun(cmd *cobra.Command, args []string) {
+	accounts := make([]database.AccountID, len(owners))
+	for i, owner := range owners {
+		accountID, _, err := resolveAccount(owner)
+		if err != nil {
+			log.Fatal(err)
+		}
+		accounts[i] = accountID
+	}
+
+	ms, err := database.NewMultiSig(accounts, threshold)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	data, err := json.MarshalIndent(ms, "", "    ")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Multisig account: %s\n", ms.AccountID())
+
+	if out == "" {
+		fmt.Println(string(data))
+		return
+	}
+
+	if err := os.WriteFile(out, append(data, '\n'), 0600); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func multiSigProposeRun(cmd *cobra.Command, args []string) {
+	content, err := os.ReadFile(multiSigFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var ms database.MultiSig
+	if err := json.Unmarshal(content, &ms); err != nil {
+		log.Fatal(err)
+	}
+
+	privateKey, err := loadPrivateKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tx, err := buildTx(cmd, ms.AccountID())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	signedTx, err := tx.ToMultiSigTx(ms)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	signedTx, err = signedTx.Approve(privateKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := writeSignedTx(out, signedTx); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func multiSigApproveRun(cmd *cobra.Command, args []string) {
+	signedTx, err := readSignedTx(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	privateKey, err := loadPrivateKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	signedTx, err = signedTx.Approve(privateKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Without an output file, update the transaction file in place.
+	path := out
+	if path == "" && len(args) > 0 && args[0] != "-" {
+		path = args[0]
+	}
+
+	if err := writeSignedTx(path, signedTx); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func multiSigSubmitRun(cmd *cobra.Command, args []string) {
+	signedTx, err := readSignedTx(args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	approvers, err := signedTx.Approvers()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(approvers) < int(signedTx.Approvals.Threshold) {
+		log.Fatalf("transaction has %d of %d required signatures", len(approvers), signedTx.Approvals.Threshold)
+	}
+
+	if err := submitTx(signedTx); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/nameservice"
+)
+
+// resolveAccount converts the value into an account id. Values that aren't
+// already an account id are treated as a name and resolved with the node's
+// name registry, falling back to the names in the local accounts folder.
+// The resolved flag reports if a name had to be resolved.
+func resolveAccount(value string) (accountID database.AccountID, resolved bool, err error) {
+	if database.AccountID(value).IsAccountID() {
+		return database.AccountID(value), false, nil
+	}
+
+	if names, err := queryNames(); err == nil {
+		if accountID, exists := names[value]; exists {
+			return accountID, true, nil
+		}
+	}
+
+	ns, err := nameservice.New(accountPath)
+	if err != nil {
+		return "", false, err
+	}
+
+	for accountID, name := range ns.Copy() {
+		if name == value {
+			return accountID, true, nil
+		}
+	}
+
+	return "", false, fmt.Errorf("%q is not an account or a known name", value)
+}
+
+// confirm asks the user to approve before continuing.
+func confirm(question string) error {
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return errors.New("no confirmation received, use --yes to skip it")
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+
+	return errors.New("cancelled")
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/spf13/cobra"
+)
+
+var nameCmd = &cobra.Command{
+	Use:   "name",
+	Short: "Manage names in the on-chain name registry",
+}
+
+var nameRegisterCmd = &cobra.Command{
+	Use:   "register <name>",
+	Short: "Register an unowned name to the to account",
+	Args:  cobra.ExactArgs(1),
+	Run:   nameRegisterRun,
+}
+
+var nameTransferCmd = &cobra.Command{
+	Use:   "transfer <name>",
+	Short: "Transfer a name owned by the account to the to account",
+	Args:  cobra.ExactArgs(1),
+	Run:   nameTransferRun,
+}
+
+func init() {
+	rootCmd.AddCommand(nameCmd)
+	nameCmd.AddCommand(nameRegisterCmd)
+	nameCmd.AddCommand(nameTransferCmd)
+
+	for _, cmd := range []*cobra.Command{nameRegisterCmd, nameTransferCmd} {
+		cmd.Flags().StringVarP(&url, "url", "u", "http://localhost:8080", "Url of the node.")
+		addTxFlags(cmd)
+
+		// The data is the name operation.
+		cmd.Flags().MarkHidden("data")
+	}
+	nameRegisterCmd.MarkFlagRequired("to")
+	nameTransferCmd.MarkFlagRequired("to")
+}
+
+func nameRegisterRun(cmd *cobra.Command, args []string) {
+	nameRun(cmd, database.NameRegister, args[0])
+}
+
+func nameTransferRun(cmd *cobra.Command, args []string) {
+	nameRun(cmd, database.NameTransfer, args[0])
+}
+
+// nameRun signs and submits a transaction carrying the name operation.
+func nameRun(cmd *cobra.Command, op string, name string) {
+	privateKey, err := loadPrivateKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if data, err = database.NewNameOp(op, name); err != nil {
+		log.Fatal(err)
+	}
+
+	signedTx, err := signTx(cmd, privateKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := submitTx(signedTx); err != nil {
+		log.Fatal(err)
+	}
+}
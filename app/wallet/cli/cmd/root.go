@@ -2,20 +2,27 @@
 package cmd
 
 import (
+	"crypto/ecdsa"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/hdwallet"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/keystore"
 	"github.com/spf13/cobra"
 )
 
 var (
-	accountName string
-	accountPath string
+	accountName    string
+	accountPath    string
+	accountIndex   int
+	passphraseFile string
 )
 
 const (
-	keyExtenstion = ".ecdsa"
+	passphraseEnv = "WALLET_PASSPHRASE"
+	extMnemonic   = ".mnemonic"
 )
 
 var rootCmd = &cobra.Command{
@@ -26,6 +33,8 @@ var rootCmd = &cobra.Command{
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&accountName, "account", "a", "private.ecdsa", "The account to use.")
 	rootCmd.PersistentFlags().StringVarP(&accountPath, "account-path", "p", "zblock/accounts/", "Path to the directory with private keys.")
+	rootCmd.PersistentFlags().IntVarP(&accountIndex, "index", "i", -1, "Index of the account derived from the account's mnemonic.")
+	rootCmd.PersistentFlags().StringVar(&passphraseFile, "passphrase-file", "", "File with the passphrase for an encrypted key, otherwise $"+passphraseEnv+" or a prompt is used.")
 }
 
 func Execute() {
@@ -35,10 +44,69 @@ func Execute() {
 	}
 }
 
-func getPrivateKeyPath() string {
-	if !strings.HasSuffix(accountName, keyExtenstion) {
-		accountName += keyExtenstion
+// getAccountName returns the account name without any key file extension.
+func getAccountName() string {
+	name := strings.TrimSuffix(accountName, keystore.ExtECDSA)
+	name = strings.TrimSuffix(name, keystore.ExtKeystore)
+	return strings.TrimSuffix(name, extMnemonic)
+}
+
+// getPrivateKeyPath returns the path to the account's key file with the
+// specified extension.
+func getPrivateKeyPath(ext string) string {
+	return filepath.Join(accountPath, getAccountName()+ext)
+}
+
+// loadPrivateKey loads the account's private key, decrypting the keystore
+// file if one exists. When an index is provided, the key is derived from the
+// account's mnemonic instead.
+func loadPrivateKey() (*ecdsa.PrivateKey, error) {
+	if accountIndex >= 0 {
+		seed, err := loadSeed()
+		if err != nil {
+			return nil, err
+		}
+
+		return hdwallet.Derive(seed, uint32(accountIndex))
+	}
+
+	return keystore.LoadKey(accountPath, getAccountName(), func() (string, error) {
+		return getPassphrase(false)
+	})
+}
+
+// getPassphrase returns the passphrase from the passphrase file, the
+// environment or by prompting on the terminal in that order. If confirm is
+// true, a prompted passphrase must be entered twice.
+func getPassphrase(confirm bool) (string, error) {
+	if passphraseFile != "" {
+		return keystore.ReadPassphrase(passphraseFile)
+	}
+
+	if passphrase, exists := os.LookupEnv(passphraseEnv); exists {
+		return passphrase, nil
+	}
+
+	return keystore.PromptPassphrase(fmt.Sprintf("Passphrase for %s: ", getAccountName()), confirm)
+}
+
+// loadSeed decrypts the account's mnemonic file and converts the mnemonic
+// into the seed used to derive accounts.
+func loadSeed() ([]byte, error) {
+	content, err := os.ReadFile(getPrivateKeyPath(extMnemonic))
+	if err != nil {
+		return nil, err
+	}
+
+	passphrase, err := getPassphrase(false)
+	if err != nil {
+		return nil, err
+	}
+
+	mnemonic, err := keystore.DecryptData(content, passphrase)
+	if err != nil {
+		return nil, err
 	}
 
-	return filepath.Join(accountPath, accountName)
+	return hdwallet.Seed(strings.TrimSpace(string(mnemonic)), "")
 }
@@ -9,19 +9,21 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/spf13/cobra"
 )
 
 var (
-	nonce uint64
-	from  string
-	to    string
-	value uint64
-	tip   uint64
-	data  []byte
+	chainID uint16
+	nonce   uint64
+	from    string
+	to      string
+	value   uint64
+	tip     uint64
+	data    []byte
+	yes     bool
 )
 
 var sendCmd = &cobra.Command{
@@ -33,55 +35,159 @@ var sendCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(sendCmd)
 	sendCmd.Flags().StringVarP(&url, "url", "u", "http://localhost:8080", "Url of the node.")
-	sendCmd.Flags().Uint64VarP(&nonce, "nonce", "n", 0, "id for the transaction.")
-	sendCmd.Flags().StringVarP(&from, "from", "f", "", "Who is sending the transaction.")
-	sendCmd.Flags().StringVarP(&to, "to", "t", "", "Who is receiving the transaction.")
-	sendCmd.Flags().Uint64VarP(&value, "value", "v", 0, "Value to send.")
-	sendCmd.Flags().Uint64VarP(&tip, "tip", "c", 0, "Tip to send.")
-	sendCmd.Flags().BytesHexVarP(&data, "data", "d", nil, "Data to send.")
+	addTxFlags(sendCmd)
+}
+
+// addTxFlags adds the flags describing a transaction to the command.
+func addTxFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint16Var(&chainID, "chain-id", 0, "Chain id for the transaction, overrides the chain id from the node's genesis.")
+	cmd.Flags().Uint64VarP(&nonce, "nonce", "n", 0, "id for the transaction, overrides the nonce calculated from the node.")
+	cmd.Flags().StringVarP(&from, "from", "f", "", "Who is sending the transaction as an account or name, overrides the account of the private key.")
+	cmd.Flags().StringVarP(&to, "to", "t", "", "Who is receiving the transaction as an account or name.")
+	cmd.Flags().Uint64VarP(&value, "value", "v", 0, "Value to send.")
+	cmd.Flags().Uint64VarP(&tip, "tip", "c", 0, "Tip to send.")
+	cmd.Flags().BytesHexVarP(&data, "data", "d", nil, "Data to send.")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Don't ask to confirm accounts resolved from names.")
 }
 
 func sendRun(cmd *cobra.Command, args []string) {
-	privateKey, err := crypto.LoadECDSA(getPrivateKeyPath())
+	privateKey, err := loadPrivateKey()
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	sendWithDetails(privateKey)
-}
-
-func sendWithDetails(privateKey *ecdsa.PrivateKey) {
-	fromAccount, err := database.ToAccountID(from)
+	signedTx, err := signTx(cmd, privateKey)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	toAccount, err := database.ToAccountID(to)
-	if err != nil {
+	if err := submitTx(signedTx); err != nil {
 		log.Fatal(err)
 	}
+}
 
-	const chainID = 1
-	tx, err := database.NewTx(chainID, nonce, fromAccount, toAccount, value, tip, data)
+// signTx builds the transaction described by the flags and signs it with the
+// private key.
+func signTx(cmd *cobra.Command, privateKey *ecdsa.PrivateKey) (database.SignedTx, error) {
+	tx, err := buildTx(cmd, database.PublicKeyToAccountID(privateKey.PublicKey))
 	if err != nil {
-		log.Fatal(err)
+		return database.SignedTx{}, err
 	}
 
-	signedTx, err := tx.Sign(privateKey)
+	return tx.Sign(privateKey)
+}
+
+// buildTx builds the transaction described by the flags from the specified
+// account unless the from flag overrides it. The node is only asked for the
+// chain id and nonce when they are not provided, so a transaction can be
+// built without network access.
+func buildTx(cmd *cobra.Command, fromAccount database.AccountID) (database.Tx, error) {
+	var resolved []string
+
+	if cmd.Flags().Changed("from") {
+		var fromResolved bool
+		var err error
+		fromAccount, fromResolved, err = resolveAccount(from)
+		if err != nil {
+			return database.Tx{}, err
+		}
+		if fromResolved {
+			resolved = append(resolved, fmt.Sprintf("from %s: %s", from, fromAccount))
+		}
+	}
+
+	toAccount, toResolved, err := resolveAccount(to)
 	if err != nil {
-		log.Fatal(err)
+		return database.Tx{}, err
+	}
+	if toResolved {
+		resolved = append(resolved, fmt.Sprintf("to %s: %s", to, toAccount))
+	}
+
+	// Names are convenient but a wrong name sends money to the wrong
+	// account, so show the resolved accounts before signing.
+	if len(resolved) > 0 && !yes {
+		if err := confirm(fmt.Sprintf("Sign transaction %s?", strings.Join(resolved, ", "))); err != nil {
+			return database.Tx{}, err
+		}
 	}
 
+	if !cmd.Flags().Changed("chain-id") {
+		gen, err := queryGenesis()
+		if err != nil {
+			return database.Tx{}, fmt.Errorf("query chain id, use --chain-id when offline: %w", err)
+		}
+		chainID = gen.ChainID
+	}
+
+	if !cmd.Flags().Changed("nonce") {
+		nonce, err = queryNextNonce(fromAccount)
+		if err != nil {
+			return database.Tx{}, fmt.Errorf("query nonce, use --nonce when offline: %w", err)
+		}
+	}
+
+	return database.NewTx(chainID, nonce, fromAccount, toAccount, value, tip, data)
+}
+
+// submitTx posts the signed transaction to the node and writes the node's
+// response to stdout.
+func submitTx(signedTx database.SignedTx) error {
 	data, err := json.Marshal(signedTx)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 
 	resp, err := http.Post(fmt.Sprintf("%s/v1/tx/submit", url), "application/json", bytes.NewBuffer(data))
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 	defer resp.Body.Close()
 
-	io.Copy(os.Stdout, resp.Body)
+	if _, err := io.Copy(os.Stdout, resp.Body); err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("submit failed with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// readSignedTx reads a signed transaction from the file, or from stdin when
+// no file or "-" is provided.
+func readSignedTx(args []string) (database.SignedTx, error) {
+	r := io.Reader(os.Stdin)
+	if len(args) > 0 && args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return database.SignedTx{}, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var signedTx database.SignedTx
+	if err := json.NewDecoder(r).Decode(&signedTx); err != nil {
+		return database.SignedTx{}, fmt.Errorf("decoding signed transaction: %w", err)
+	}
+
+	return signedTx, nil
+}
+
+// writeSignedTx writes the signed transaction to the file, or to stdout when
+// no file is provided.
+func writeSignedTx(path string, signedTx database.SignedTx) error {
+	data, err := json.MarshalIndent(signedTx, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	if path == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0600)
 }
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+)
+
+var out string
+
+var signCmd = &cobra.Command{
+	Use:   "sign",
+	Short: "Sign a transaction without sending it",
+	Long:  "Sign a transaction without sending it. Provide --chain-id and --nonce to sign without access to a node.",
+	Run:   signRun,
+}
+
+func init() {
+	rootCmd.AddCommand(signCmd)
+	signCmd.Flags().StringVarP(&url, "url", "u", "http://localhost:8080", "Url of the node.")
+	signCmd.Flags().StringVarP(&out, "out", "o", "", "File to write the signed transaction to, stdout by default.")
+	addTxFlags(signCmd)
+}
+
+func signRun(cmd *cobra.Command, args []string) {
+	privateKey, err := loadPrivateKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	signedTx, err := signTx(cmd, privateKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := writeSignedTx(out, signedTx); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/spf13/cobra"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Print the status of a transaction sent by the account",
+	Run:   statusRun,
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+	statusCmd.Flags().StringVarP(&url, "url", "u", "http://localhost:8080", "Url of the node.")
+	statusCmd.Flags().Uint64VarP(&nonce, "nonce", "n", 0, "id of the transaction.")
+	statusCmd.Flags().StringVarP(&from, "from", "f", "", "Who sent the transaction as an account or name, overrides the account of the private key.")
+	statusCmd.MarkFlagRequired("nonce")
+}
+
+// statusRun looks up the transaction the account sent with the nonce and
+// prints whether it is still pending in the node's mempool or was dropped.
+func statusRun(cmd *cobra.Command, args []string) {
+	var accountID database.AccountID
+	switch {
+	case cmd.Flags().Changed("from"):
+		var err error
+		accountID, _, err = resolveAccount(from)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+	default:
+		privateKey, err := loadPrivateKey()
+		if err != nil {
+			log.Fatal(err)
+		}
+		accountID = database.PublicKeyToAccountID(privateKey.PublicKey)
+	}
+
+	status, err := queryTxStatus(accountID, nonce)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Transaction %s:%d: %s\n", accountID, nonce, status.Status)
+	if status.Tx != nil {
+		fmt.Printf("%s -> %s: value %d, tip %d\n", status.Tx.FromName, status.Tx.ToName, status.Tx.Value, status.Tx.Tip)
+	}
+}
@@ -31,6 +31,11 @@ func (err *RequestError) Error() string {
 	return err.Err.Error()
 }
 
+// Unwrap returns the wrapped error so the error chain can be inspected.
+func (err *RequestError) Unwrap() error {
+	return err.Err
+}
+
 // IsRequestError checks if an error of type RequestError exists.
 func IsRequestError(err error) bool {
 	var re *RequestError
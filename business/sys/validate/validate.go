@@ -0,0 +1,200 @@
+// Package validate contains the support for validating models.
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+)
+
+// tagName is the struct tag holding the comma separated validation rules for
+// a field. The supported rules are:
+//
+//	omitempty                skip the remaining rules when the field is the zero value
+//	required                 the field must not be the zero value
+//	required_without=Field   the field is required when Field is the zero value
+//	hexaccount               the field must be a hex encoded account id
+//	min=N                    a number must be at least N, a string or slice must have at least N items
+//	max=N                    a number must be at most N, a string or slice must have at most N items
+//
+// Structs, pointers to structs and slices of structs are checked recursively.
+const tagName = "validate"
+
+// Check validates the provided model against its declared tags. The errors
+// are reported per field using the JSON name of the field.
+func Check(val any) error {
+	v := reflect.ValueOf(val)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	var fields FieldErrors
+	checkValue(v, "", &fields)
+
+	if len(fields) > 0 {
+		return fields
+	}
+
+	return nil
+}
+
+// =============================================================================
+
+// checkValue walks through the value looking for structs to check.
+func checkValue(v reflect.Value, path string, fields *FieldErrors) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			checkValue(v.Elem(), path, fields)
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			checkValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i), fields)
+		}
+
+	case reflect.Struct:
+		checkStruct(v, path, fields)
+	}
+}
+
+// checkStruct applies the rules declared on each field of the struct.
+func checkStruct(v reflect.Value, path string, fields *FieldErrors) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		fv := v.Field(i)
+
+		// The fields of an embedded struct are part of this struct.
+		if sf.Anonymous {
+			checkValue(fv, path, fields)
+			continue
+		}
+
+		name := fieldPath(path, sf)
+
+		if tag := sf.Tag.Get(tagName); tag != "" {
+			if err := checkRules(v, fv, tag); err != "" {
+				*fields = append(*fields, FieldError{Field: name, Error: err})
+				continue
+			}
+		}
+
+		checkValue(fv, name, fields)
+	}
+}
+
+// checkRules applies the rules from the tag to the field and returns the
+// reason the first failing rule reported.
+func checkRules(parent reflect.Value, fv reflect.Value, tag string) string {
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+
+		switch name {
+		case "omitempty":
+			if isEmpty(fv) {
+				return ""
+			}
+
+		case "required":
+			if isEmpty(fv) {
+				return "is required"
+			}
+
+		case "required_without":
+			other := parent.FieldByName(param)
+			if !other.IsValid() {
+				return fmt.Sprintf("unknown field %s in rule %s", param, rule)
+			}
+			if isEmpty(other) && isEmpty(fv) {
+				return fmt.Sprintf("is required without %s", strings.ToLower(param))
+			}
+
+		case "hexaccount":
+			if fv.Kind() != reflect.String {
+				return fmt.Sprintf("rule %s only applies to strings", rule)
+			}
+			if !database.AccountID(fv.String()).IsAccountID() {
+				return "must be a hex encoded account id"
+			}
+
+		case "min", "max":
+			limit, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				return fmt.Sprintf("invalid rule %s", rule)
+			}
+
+			size, isLength, ok := measure(fv)
+			if !ok {
+				return fmt.Sprintf("rule %s doesn't apply to %s", rule, fv.Kind())
+			}
+
+			switch {
+			case name == "min" && size < limit && isLength:
+				return fmt.Sprintf("must have at least %s items", param)
+			case name == "min" && size < limit:
+				return fmt.Sprintf("must be at least %s", param)
+			case name == "max" && size > limit && isLength:
+				return fmt.Sprintf("must have at most %s items", param)
+			case name == "max" && size > limit:
+				return fmt.Sprintf("must be at most %s", param)
+			}
+
+		default:
+			return fmt.Sprintf("unknown rule %s", rule)
+		}
+	}
+
+	return ""
+}
+
+// isEmpty reports if the value is the zero value or an empty slice or map.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+
+	return v.IsZero()
+}
+
+// measure returns the number the min and max rules compare against, which is
+// the value of a number or the length of a string, slice or map.
+func measure(v reflect.Value) (size float64, isLength bool, ok bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), false, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), false, true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), false, true
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()), true, true
+	}
+
+	return 0, false, false
+}
+
+// fieldPath returns the path of the field using its JSON name.
+func fieldPath(path string, sf reflect.StructField) string {
+	name := sf.Name
+	if tag, _, _ := strings.Cut(sf.Tag.Get("json"), ","); tag != "" && tag != "-" {
+		name = tag
+	}
+
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
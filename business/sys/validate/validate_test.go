@@ -0,0 +1,162 @@
+package validate_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/sys/validate"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+)
+
+const account = "0xF01813E4B85e178A83e29B8E7bF26BD830a25f32"
+
+type owner struct {
+	Account string `json:"account" validate:"required,hexaccount"`
+}
+
+type model struct {
+	Name     string  `json:"name" validate:"required"`
+	Nick     string  `json:"nick" validate:"omitempty,min=3"`
+	Backup   string  `json:"backup" validate:"required_without=Name"`
+	To       string  `json:"to" validate:"omitempty,hexaccount"`
+	Count    int     `json:"count" validate:"min=1,max=5"`
+	Tags     []int   `json:"tags" validate:"omitempty,max=2"`
+	Owner    *owner  `json:"owner"`
+	Owners   []owner `json:"owners"`
+	NoJSON   string  `validate:"omitempty,max=1"`
+	internal string  `validate:"required"` // Unexported fields are never checked.
+}
+
+// valid returns a model that passes every rule.
+func valid() model {
+	return model{
+		Name:  "bill",
+		Count: 1,
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tt := []struct {
+		name   string
+		change func(m *model)
+		exp    validate.FieldErrors
+	}{
+		{
+			name:   "valid",
+			change: func(m *model) {},
+		},
+		{
+			name:   "required",
+			change: func(m *model) { m.Name = ""; m.Backup = "b" },
+			exp:    validate.FieldErrors{{Field: "name", Error: "is required"}},
+		},
+		{
+			name:   "required_without",
+			change: func(m *model) { m.Name = "" },
+			exp: validate.FieldErrors{
+				{Field: "name", Error: "is required"},
+				{Field: "backup", Error: "is required without name"},
+			},
+		},
+		{
+			name:   "omitempty skips the empty value",
+			change: func(m *model) { m.Nick = ""; m.Tags = []int{} },
+		},
+		{
+			name:   "omitempty checks the set value",
+			change: func(m *model) { m.Nick = "ab" },
+			exp:    validate.FieldErrors{{Field: "nick", Error: "must have at least 3 items"}},
+		},
+		{
+			name:   "hexaccount",
+			change: func(m *model) { m.To = "0x1234" },
+			exp:    validate.FieldErrors{{Field: "to", Error: "must be a hex encoded account id"}},
+		},
+		{
+			name:   "hexaccount valid",
+			change: func(m *model) { m.To = account },
+		},
+		{
+			name:   "min number",
+			change: func(m *model) { m.Count = 0 },
+			exp:    validate.FieldErrors{{Field: "count", Error: "must be at least 1"}},
+		},
+		{
+			name:   "max number",
+			change: func(m *model) { m.Count = 6 },
+			exp:    validate.FieldErrors{{Field: "count", Error: "must be at most 5"}},
+		},
+		{
+			name:   "max length",
+			change: func(m *model) { m.Tags = []int{1, 2, 3} },
+			exp:    validate.FieldErrors{{Field: "tags", Error: "must have at most 2 items"}},
+		},
+		{
+			name:   "nested pointer",
+			change: func(m *model) { m.Owner = &owner{Account: "bill"} },
+			exp:    validate.FieldErrors{{Field: "owner.account", Error: "must be a hex encoded account id"}},
+		},
+		{
+			name:   "nil pointer is skipped",
+			change: func(m *model) { m.Owner = nil },
+		},
+		{
+			name:   "nested slice",
+			change: func(m *model) { m.Owners = []owner{{Account: account}, {}} },
+			exp:    validate.FieldErrors{{Field: "owners[1].account", Error: "is required"}},
+		},
+		{
+			name:   "field without a json name",
+			change: func(m *model) { m.NoJSON = "ab" },
+			exp:    validate.FieldErrors{{Field: "NoJSON", Error: "must have at most 1 items"}},
+		},
+	}
+
+	for _, tst := range tt {
+		t.Run(tst.name, func(t *testing.T) {
+			m := valid()
+			tst.change(&m)
+
+			err := validate.Check(&m)
+			if tst.exp == nil {
+				if err != nil {
+					t.Fatalf("Should pass validation, got %v", err)
+				}
+				return
+			}
+
+			var fe validate.FieldErrors
+			if !errors.As(err, &fe) {
+				t.Fatalf("Should get field errors, got %v", err)
+			}
+			if !reflect.DeepEqual(fe, tst.exp) {
+				t.Errorf("Should get the field errors\ngot: %v\nexp: %v", fe, tst.exp)
+			}
+		})
+	}
+}
+
+func TestCheckTx(t *testing.T) {
+	tx := database.Tx{
+		ChainID: 1,
+		Nonce:   0,
+		FromID:  account,
+		ToID:    account,
+	}
+
+	var fe validate.FieldErrors
+	if !errors.As(validate.Check(tx), &fe) {
+		t.Fatalf("Should reject a transaction with a nonce of zero")
+	}
+
+	exp := validate.FieldErrors{{Field: "nonce", Error: "is required"}}
+	if !reflect.DeepEqual(fe, exp) {
+		t.Errorf("Should only get the nonce error\ngot: %v\nexp: %v", fe, exp)
+	}
+
+	tx.Nonce = 1
+	if err := validate.Check(tx); err != nil {
+		t.Errorf("Should accept a transaction with a nonce of one, got %v", err)
+	}
+}
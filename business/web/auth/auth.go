@@ -0,0 +1,254 @@
+// Package auth provides support for authenticating node-to-node requests. A
+// node signs the request with its beneficiary key, covering the account, the
+// method, the path, a timestamp and a hash of the body. The receiving node
+// verifies the signature, checks the account against its allowlist of peers
+// and rejects requests outside the time window or seen before.
+package auth
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/signature"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/tracing"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Set of headers carrying the authentication information.
+const (
+	HeaderAccount   = "X-Node-Account"
+	HeaderTimestamp = "X-Node-Timestamp"
+	HeaderSignature = "X-Node-Signature"
+)
+
+// ErrNotAllowed is returned when a request is properly signed by an account
+// that is not in the allowlist of peers.
+var ErrNotAllowed = errors.New("account is not an allowed peer")
+
+// claims represents the information about a request that is signed.
+type claims struct {
+	Account   database.AccountID `json:"account"`
+	Method    string             `json:"method"`
+	Path      string             `json:"path"`
+	Timestamp int64              `json:"timestamp"`
+	BodyHash  string             `json:"body_hash"`
+}
+
+// Sign adds the headers authenticating the request as coming from the
+// account of the private key. The body is read and replaced so the request
+// can still be sent.
+func Sign(r *http.Request, privateKey *ecdsa.PrivateKey) error {
+	body, err := readBody(r)
+	if err != nil {
+		return err
+	}
+
+	c := claims{
+		Account:   database.PublicKeyToAccountID(privateKey.PublicKey),
+		Method:    r.Method,
+		Path:      r.URL.RequestURI(),
+		Timestamp: time.Now().UTC().UnixMilli(),
+		BodyHash:  crypto.Keccak256Hash(body).Hex(),
+	}
+
+	v, rr, s, err := signature.Sign(c, privateKey)
+	if err != nil {
+		return fmt.Errorf("signing request: %w", err)
+	}
+
+	r.Header.Set(HeaderAccount, string(c.Account))
+	r.Header.Set(HeaderTimestamp, strconv.FormatInt(c.Timestamp, 10))
+	r.Header.Set(HeaderSignature, signature.SignatureString(v, rr, s))
+
+	return nil
+}
+
+// =============================================================================
+
+// Transport is an http.RoundTripper that signs every request with the node's
+// private key before sending it, so the calls a node makes to its peers pass
+// their Authenticate middleware. The trace of the request context is
+// propagated with the traceparent header.
+type Transport struct {
+	PrivateKey *ecdsa.PrivateKey
+	Base       http.RoundTripper
+}
+
+// NewClient constructs an http.Client for calling the private API of peers
+// that signs every request with the private key.
+func NewClient(privateKey *ecdsa.PrivateKey, timeout time.Duration) *http.Client {
+	return &http.Client{
+		Transport: &Transport{PrivateKey: privateKey},
+		Timeout:   timeout,
+	}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+
+	// A RoundTripper must not modify the request it was given.
+	r = r.Clone(r.Context())
+	if r.Body != nil && r.GetBody != nil {
+		body, err := r.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("copying body: %w", err)
+		}
+		r.Body = body
+	}
+
+	tracing.Inject(r.Context(), r.Header)
+
+	if err := Sign(r, t.PrivateKey); err != nil {
+		return nil, err
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return base.RoundTrip(r)
+}
+
+// =============================================================================
+
+// Auth verifies the requests signed by peers.
+type Auth struct {
+	peers  map[database.AccountID]bool
+	window time.Duration
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// New constructs an Auth that accepts requests from the specified peers with
+// a timestamp within the window of the current time.
+func New(peers []database.AccountID, window time.Duration) *Auth {
+	a := Auth{
+		peers:  make(map[database.AccountID]bool),
+		window: window,
+		seen:   make(map[string]time.Time),
+	}
+
+	// Accounts are checksummed so peers can be configured in any case.
+	for _, peer := range peers {
+		a.peers[toChecksum(peer)] = true
+	}
+
+	return &a
+}
+
+// Verify checks the request is signed by an allowed peer within the time
+// window and hasn't been seen before. It returns the account of the peer.
+func (a *Auth) Verify(r *http.Request) (database.AccountID, error) {
+	sig := r.Header.Get(HeaderSignature)
+	if sig == "" {
+		return "", errors.New("request is not signed")
+	}
+
+	accountID, err := database.ToAccountID(r.Header.Get(HeaderAccount))
+	if err != nil {
+		return "", fmt.Errorf("invalid %s header: %w", HeaderAccount, err)
+	}
+
+	timestamp, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s header: %w", HeaderTimestamp, err)
+	}
+
+	now := time.Now().UTC()
+	sent := time.UnixMilli(timestamp)
+	if sent.Before(now.Add(-a.window)) || sent.After(now.Add(a.window)) {
+		return "", errors.New("request timestamp is outside the allowed window")
+	}
+
+	body, err := readBody(r)
+	if err != nil {
+		return "", err
+	}
+
+	c := claims{
+		Account:   accountID,
+		Method:    r.Method,
+		Path:      r.URL.RequestURI(),
+		Timestamp: timestamp,
+		BodyHash:  crypto.Keccak256Hash(body).Hex(),
+	}
+
+	address, err := signature.VerifyMessage(c, sig)
+	if err != nil {
+		return "", fmt.Errorf("invalid signature: %w", err)
+	}
+
+	if database.AccountID(address) != toChecksum(accountID) {
+		return "", errors.New("signature doesn't match the account")
+	}
+	accountID = database.AccountID(address)
+
+	if !a.peers[accountID] {
+		return "", ErrNotAllowed
+	}
+
+	// A signed request can be captured and sent again while it's still in
+	// the window, so each request is only accepted once. The claims are used
+	// rather than the signature since a signature can be altered and still
+	// be valid.
+	if err := a.markSeen(signature.Hash(c), sent.Add(a.window), now); err != nil {
+		return "", err
+	}
+
+	return accountID, nil
+}
+
+// markSeen records the request until it expires and fails if it was already
+// recorded. Expired requests are removed along the way.
+func (a *Auth) markSeen(hash string, expires time.Time, now time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for h, exp := range a.seen {
+		if now.After(exp) {
+			delete(a.seen, h)
+		}
+	}
+
+	if _, exists := a.seen[hash]; exists {
+		return errors.New("request has already been received")
+	}
+	a.seen[hash] = expires
+
+	return nil
+}
+
+// =============================================================================
+
+// toChecksum returns the account in its checksummed form.
+func toChecksum(accountID database.AccountID) database.AccountID {
+	return database.AccountID(common.HexToAddress(string(accountID)).Hex())
+}
+
+// readBody reads the body of the request and replaces it so it can be read
+// again.
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading body: %w", err)
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
@@ -0,0 +1,140 @@
+package auth_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/web/auth"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestVerify(t *testing.T) {
+	peerKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Should be able to generate the peer key: %s", err)
+	}
+	peer := database.PublicKeyToAccountID(peerKey.PublicKey)
+
+	otherKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Should be able to generate the other key: %s", err)
+	}
+
+	// signed returns a request to the private API signed with the key.
+	signed := func(t *testing.T, body string, sign bool) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/v1/node/tx/submit", strings.NewReader(body))
+		if !sign {
+			return r
+		}
+		if err := auth.Sign(r, peerKey); err != nil {
+			t.Fatalf("Should be able to sign the request: %s", err)
+		}
+		return r
+	}
+
+	t.Run("good signature", func(t *testing.T) {
+		a := auth.New([]database.AccountID{peer}, time.Minute)
+
+		accountID, err := a.Verify(signed(t, `{"nonce":1}`, true))
+		if err != nil {
+			t.Fatalf("Should accept the signed request: %s", err)
+		}
+		if accountID != peer {
+			t.Errorf("Should get the peer account, got %s, exp %s", accountID, peer)
+		}
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		a := auth.New([]database.AccountID{peer}, time.Minute)
+
+		r := signed(t, `{"nonce":1}`, true)
+		replay := r.Clone(r.Context())
+		replay.Body = signed(t, `{"nonce":1}`, false).Body
+
+		if _, err := a.Verify(r); err != nil {
+			t.Fatalf("Should accept the first request: %s", err)
+		}
+		if _, err := a.Verify(replay); err == nil {
+			t.Errorf("Should reject the same request sent again")
+		}
+	})
+
+	t.Run("stale timestamp", func(t *testing.T) {
+		a := auth.New([]database.AccountID{peer}, time.Minute)
+
+		r := signed(t, `{"nonce":1}`, true)
+		r.Header.Set(auth.HeaderTimestamp, strconv.FormatInt(time.Now().Add(-2*time.Minute).UnixMilli(), 10))
+
+		if _, err := a.Verify(r); err == nil {
+			t.Errorf("Should reject a request outside the window")
+		}
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		a := auth.New([]database.AccountID{peer}, time.Minute)
+
+		r := signed(t, `{"nonce":1}`, true)
+		r.Body = signed(t, `{"nonce":2}`, false).Body
+
+		if _, err := a.Verify(r); err == nil {
+			t.Errorf("Should reject a request whose body was changed")
+		}
+	})
+
+	t.Run("non-peer", func(t *testing.T) {
+		a := auth.New([]database.AccountID{database.PublicKeyToAccountID(otherKey.PublicKey)}, time.Minute)
+
+		if _, err := a.Verify(signed(t, `{"nonce":1}`, true)); !errors.Is(err, auth.ErrNotAllowed) {
+			t.Errorf("Should get ErrNotAllowed for an account that isn't a peer, got %v", err)
+		}
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		a := auth.New([]database.AccountID{peer}, time.Minute)
+
+		if _, err := a.Verify(signed(t, `{"nonce":1}`, false)); err == nil {
+			t.Errorf("Should reject a request that isn't signed")
+		}
+	})
+}
+
+func TestTransport(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Should be able to generate the key: %s", err)
+	}
+
+	a := auth.New([]database.AccountID{database.PublicKeyToAccountID(privateKey.PublicKey)}, time.Minute)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := a.Verify(r); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := auth.NewClient(privateKey, 5*time.Second)
+
+	// Each call carries a different body so two calls in the same
+	// millisecond aren't taken as a replay.
+	for i := 1; i <= 2; i++ {
+		body := strings.NewReader(`{"nonce":` + strconv.Itoa(i) + `}`)
+		resp, err := client.Post(srv.URL+"/v1/node/tx/submit", "application/json", body)
+		if err != nil {
+			t.Fatalf("Should be able to call the peer: %s", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("Should be authenticated by the peer on call %d, got %d", i, resp.StatusCode)
+		}
+	}
+}
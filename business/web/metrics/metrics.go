@@ -5,6 +5,8 @@ import (
 	"context"
 	"expvar"
 	"runtime"
+	"sync"
+	"time"
 )
 
 // This holds the single instance of the metrics value needed for
@@ -22,6 +24,14 @@ type metrics struct {
 	requests   *expvar.Int
 	errors     *expvar.Int
 	panics     *expvar.Int
+
+	rateLimitedIPs      *expvar.Int
+	rateLimitedAccounts *expvar.Int
+	txRejected          *expvar.Map
+
+	mu        sync.RWMutex
+	durations map[route]*histogram
+	gauges    []gauge
 }
 
 // init constructs the metrics value that will be used to capture metrics.
@@ -34,7 +44,24 @@ func init() {
 		requests:   expvar.NewInt("requests"),
 		errors:     expvar.NewInt("errors"),
 		panics:     expvar.NewInt("panics"),
+
+		rateLimitedIPs:      expvar.NewInt("rate_limited_ips"),
+		rateLimitedAccounts: expvar.NewInt("rate_limited_accounts"),
+		txRejected:          expvar.NewMap("tx_rejected"),
+
+		durations: make(map[route]*histogram),
 	}
+
+	expvar.Publish("request_durations", expvar.Func(func() any {
+		m.mu.RLock()
+		defer m.mu.RUnlock()
+
+		durations := make(map[string]histogramSnapshot)
+		for rt, h := range m.durations {
+			durations[rt.method+" "+rt.path] = h.snapshot()
+		}
+		return durations
+	}))
 }
 
 // =============================================================================
@@ -87,3 +114,68 @@ func AddPanics(ctx context.Context) {
 		v.panics.Add(1)
 	}
 }
+
+// AddRateLimitedIPs increments the metric for requests rejected by the rate
+// limit on the remote IP by 1.
+func AddRateLimitedIPs(ctx context.Context) {
+	if v, ok := ctx.Value(key).(*metrics); ok {
+		v.rateLimitedIPs.Add(1)
+	}
+}
+
+// AddRateLimitedAccounts increments the metric for requests rejected by the
+// rate limit on the sending account by 1.
+func AddRateLimitedAccounts(ctx context.Context) {
+	if v, ok := ctx.Value(key).(*metrics); ok {
+		v.rateLimitedAccounts.Add(1)
+	}
+}
+
+// AddTxRejected increments the metric for transactions rejected for the
+// specified reason by 1.
+func AddTxRejected(ctx context.Context, reason string) {
+	if v, ok := ctx.Value(key).(*metrics); ok {
+		v.txRejected.Add(reason, 1)
+	}
+}
+
+// ObserveRequest records how long a request to the route took. The path is
+// the route pattern, like /v1/tx/status/:account/:nonce, so the number of
+// series stays bounded.
+func ObserveRequest(ctx context.Context, method string, path string, duration time.Duration) {
+	v, ok := ctx.Value(key).(*metrics)
+	if !ok {
+		return
+	}
+
+	rt := route{method: method, path: path}
+
+	v.mu.RLock()
+	h, exists := v.durations[rt]
+	v.mu.RUnlock()
+
+	if !exists {
+		v.mu.Lock()
+		if h, exists = v.durations[rt]; !exists {
+			h = newHistogram(durationBuckets)
+			v.durations[rt] = h
+		}
+		v.mu.Unlock()
+	}
+
+	h.observe(duration.Seconds())
+}
+
+// AddGauge registers a function that provides the current value of a metric
+// owned by another part of the application, like the size of the mempool.
+// The value is read each time the metrics are collected.
+func AddGauge(name string, help string, fn func() float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.gauges = append(m.gauges, gauge{name: name, help: help, fn: fn})
+
+	expvar.Publish(name, expvar.Func(func() any {
+		return fn()
+	}))
+}
@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"bufio"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// route identifies the requests a duration histogram is tracking.
+type route struct {
+	method string
+	path   string
+}
+
+// gauge represents a metric whose value is provided by a function.
+type gauge struct {
+	name string
+	help string
+	fn   func() float64
+}
+
+// Handler returns a handler that writes the metrics in the Prometheus text
+// exposition format.
+func Handler() http.Handler {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+		bw := bufio.NewWriter(w)
+		writePrometheus(bw)
+		bw.Flush()
+	}
+
+	return http.HandlerFunc(h)
+}
+
+// writePrometheus writes all the metrics in the Prometheus text format.
+func writePrometheus(w io.Writer) {
+	writeMetric(w, "node_requests_total", "counter", "Number of requests handled.", m.requests.Value())
+	writeMetric(w, "node_errors_total", "counter", "Number of requests that returned an error.", m.errors.Value())
+	writeMetric(w, "node_panics_total", "counter", "Number of requests that panicked.", m.panics.Value())
+	writeMetric(w, "node_goroutines", "gauge", "Number of goroutines.", runtime.NumGoroutine())
+
+	writeHeader(w, "node_rate_limited_total", "counter", "Number of requests rejected by a rate limit.")
+	fmt.Fprintf(w, "node_rate_limited_total{limit=\"ip\"} %d\n", m.rateLimitedIPs.Value())
+	fmt.Fprintf(w, "node_rate_limited_total{limit=\"account\"} %d\n", m.rateLimitedAccounts.Value())
+
+	writeHeader(w, "node_tx_rejected_total", "counter", "Number of transactions rejected at admission by reason.")
+	m.txRejected.Do(func(kv expvar.KeyValue) {
+		fmt.Fprintf(w, "node_tx_rejected_total{reason=\"%s\"} %s\n", escape(kv.Key), kv.Value.String())
+	})
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	routes := make([]route, 0, len(m.durations))
+	for rt := range m.durations {
+		routes = append(routes, rt)
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].path != routes[j].path {
+			return routes[i].path < routes[j].path
+		}
+		return routes[i].method < routes[j].method
+	})
+
+	const name = "node_request_duration_seconds"
+	writeHeader(w, name, "histogram", "Time taken to handle requests by route.")
+	for _, rt := range routes {
+		s := m.durations[rt].snapshot()
+		labels := fmt.Sprintf("method=\"%s\",route=\"%s\"", escape(rt.method), escape(rt.path))
+
+		for i, bound := range s.Bounds {
+			fmt.Fprintf(w, "%s_bucket{%s,le=\"%s\"} %d\n", name, labels, formatFloat(bound), s.Buckets[i])
+		}
+		fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, s.Count)
+		fmt.Fprintf(w, "%s_sum{%s} %s\n", name, labels, formatFloat(s.Sum))
+		fmt.Fprintf(w, "%s_count{%s} %d\n", name, labels, s.Count)
+	}
+
+	for _, g := range m.gauges {
+		writeHeader(w, g.name, "gauge", g.help)
+		fmt.Fprintf(w, "%s %s\n", g.name, formatFloat(g.fn()))
+	}
+}
+
+// =============================================================================
+
+// writeHeader writes the help and type lines for a metric.
+func writeHeader(w io.Writer, name string, typ string, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, typ)
+}
+
+// writeMetric writes a metric without labels.
+func writeMetric(w io.Writer, name string, typ string, help string, value any) {
+	writeHeader(w, name, typ, help)
+	fmt.Fprintf(w, "%s %v\n", name, value)
+}
+
+// formatFloat formats the value the way Prometheus expects.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// escape escapes a label value.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
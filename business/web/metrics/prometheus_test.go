@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// goroutines matches the goroutine count, which changes from run to run.
+var goroutines = regexp.MustCompile(`(?m)^node_goroutines \d+$`)
+
+func TestWritePrometheus(t *testing.T) {
+	ctx := Set(context.Background())
+
+	AddRequests(ctx)
+	AddRequests(ctx)
+	AddErrors(ctx)
+	AddRateLimitedIPs(ctx)
+	AddTxRejected(ctx, "mempool")
+	AddTxRejected(ctx, "invalid")
+	AddTxRejected(ctx, "mempool")
+
+	ObserveRequest(ctx, "GET", "/v1/genesis/list", 3*time.Millisecond)
+	ObserveRequest(ctx, "GET", "/v1/genesis/list", 300*time.Millisecond)
+	ObserveRequest(ctx, "POST", "/v1/tx/submit", 20*time.Second)
+
+	AddGauge("blockchain_test_size", "Size used by the test.", func() float64 { return 2.5 })
+
+	var b bytes.Buffer
+	writePrometheus(&b)
+	got := goroutines.ReplaceAll(b.Bytes(), []byte("node_goroutines 0"))
+
+	const golden = "testdata/prometheus.golden"
+	if *update {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatalf("Should be able to update the golden file: %s", err)
+		}
+	}
+
+	exp, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("Should be able to read the golden file: %s", err)
+	}
+
+	if !bytes.Equal(got, exp) {
+		t.Errorf("Should write the metrics in the golden format\ngot:\n%s\nexp:\n%s", got, exp)
+	}
+}
@@ -0,0 +1,48 @@
+package mid
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/sys/validate"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/web/auth"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/web"
+)
+
+// maxAuthBodyBytes is the largest body a peer can send. The body is read in
+// full to verify the signature before the request is authenticated.
+const maxAuthBodyBytes = 1 << 20
+
+// Authenticate verifies the request was signed by one of the allowed peers.
+func Authenticate(a *auth.Auth) web.Middleware {
+
+	// This is the actual middleware function to be executed.
+	m := func(handler web.Handler) web.Handler {
+
+		// Create the handler that will be attached in the middleware chain.
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			if r.Body != nil {
+				r.Body = http.MaxBytesReader(w, r.Body, maxAuthBodyBytes)
+			}
+
+			if _, err := a.Verify(r); err != nil {
+				var mbe *http.MaxBytesError
+				switch {
+				case errors.Is(err, auth.ErrNotAllowed):
+					return validate.NewRequestError(err, http.StatusForbidden)
+				case errors.As(err, &mbe):
+					return validate.NewRequestError(err, http.StatusRequestEntityTooLarge)
+				}
+				return validate.NewRequestError(err, http.StatusUnauthorized)
+			}
+
+			// Call the next handler.
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
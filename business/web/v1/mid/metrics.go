@@ -3,6 +3,7 @@ package mid
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/web/metrics"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/web"
@@ -20,8 +21,13 @@ func Metrics() web.Middleware {
 			// Add the metrics into the context for metric gathering.
 			ctx = metrics.Set(ctx)
 
+			v, err := web.GetValues(ctx)
+			if err != nil {
+				return web.NewShutdownError("web value missing from context")
+			}
+
 			// Call the next handler.
-			err := handler(ctx, w, r)
+			err = handler(ctx, w, r)
 
 			// Handle updating the metrics that can be handled here.
 
@@ -29,6 +35,9 @@ func Metrics() web.Middleware {
 			metrics.AddRequests(ctx)
 			metrics.AddGoroutines(ctx)
 
+			// Record how long the request took by route.
+			metrics.ObserveRequest(ctx, r.Method, web.GetRoute(ctx), time.Since(v.Now))
+
 			// Increment if there is an error flowing through the request.
 			if err != nil {
 				metrics.AddErrors(ctx)
@@ -0,0 +1,51 @@
+package mid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/sys/validate"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/web/metrics"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/ratelimit"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/web"
+)
+
+// RateLimit rejects requests once the remote IP has used up its allowance.
+// A nil limiter doesn't limit anything. The account sending a transaction is
+// limited by the handler, once the signature shows who sent it.
+func RateLimit(ipLimiter *ratelimit.Limiter) web.Middleware {
+
+	// This is the actual middleware function to be executed.
+	m := func(handler web.Handler) web.Handler {
+
+		// Create the handler that will be attached in the middleware chain.
+		h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+			ip := web.RemoteIP(r)
+			if ok, wait := ipLimiter.Allow(ip); !ok {
+				metrics.AddRateLimitedIPs(ctx)
+				return TooManyRequests(w, wait, fmt.Sprintf("too many requests from %s", ip))
+			}
+
+			// Call the next handler.
+			return handler(ctx, w, r)
+		}
+
+		return h
+	}
+
+	return m
+}
+
+// =============================================================================
+
+// TooManyRequests sets the Retry-After header and returns the error that
+// tells the client how long to wait before trying again.
+func TooManyRequests(w http.ResponseWriter, wait time.Duration, msg string) error {
+	seconds := ratelimit.RetryAfter(wait)
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+
+	return validate.NewRequestError(fmt.Errorf("%s, retry after %ds", msg, seconds), http.StatusTooManyRequests)
+}
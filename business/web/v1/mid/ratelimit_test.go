@@ -0,0 +1,46 @@
+package mid_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/sys/validate"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/business/web/v1/mid"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/ratelimit"
+)
+
+func TestRateLimit(t *testing.T) {
+	ok := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+
+	// One request for each IP every 4 seconds.
+	h := mid.RateLimit(ratelimit.New(0.25, 1))(ok)
+
+	send := func(ip string) (*httptest.ResponseRecorder, error) {
+		r := httptest.NewRequest(http.MethodPost, "/v1/tx/submit", strings.NewReader(`{}`))
+		r.RemoteAddr = ip + ":4000"
+		w := httptest.NewRecorder()
+		return w, h(r.Context(), w, r)
+	}
+
+	if _, err := send("10.0.0.1"); err != nil {
+		t.Fatalf("Should allow the first request: %s", err)
+	}
+
+	w, err := send("10.0.0.1")
+	if !validate.IsRequestError(err) || validate.GetRequestError(err).Status != http.StatusTooManyRequests {
+		t.Fatalf("Should reject the request with 429, got %v", err)
+	}
+	if got := w.Header().Get("Retry-After"); got != "4" {
+		t.Errorf("Should ask the client to retry after 4 seconds, got %q", got)
+	}
+
+	if _, err := send("10.0.0.2"); err != nil {
+		t.Errorf("Should allow a request from another IP: %s", err)
+	}
+}
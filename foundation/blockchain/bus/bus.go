@@ -0,0 +1,214 @@
+// Package bus provides a typed event bus for the blockchain. The state
+// publishes events as values of the types in this package and any number of
+// subscribers receive every event. Delivery never blocks the publisher, so
+// an event is dropped for a subscriber that falls behind and the drop is
+// counted.
+//
+// The bus took over from the foundation/events package that first fed the
+// event stream. The account filtering the stream relied on is kept here as
+// Involves, so any subscriber can select the events for a set of accounts.
+package bus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+)
+
+// Event is implemented by every event published on the bus. The name
+// identifies the type of event and the string form is what gets logged.
+type Event interface {
+	Name() string
+	String() string
+}
+
+// AccountEvent is implemented by the events that relate to accounts.
+type AccountEvent interface {
+	Event
+	Accounts() []database.AccountID
+}
+
+// Involves reports if the event relates to any of the specified accounts. An
+// empty list of accounts matches every event and an event that doesn't relate
+// to any account, like a shutdown, matches every list.
+func Involves(ev Event, accounts []database.AccountID) bool {
+	ae, ok := ev.(AccountEvent)
+	if !ok || len(accounts) == 0 {
+		return true
+	}
+
+	for _, account := range accounts {
+		for _, eventAccount := range ae.Accounts() {
+			if account == eventAccount {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Message is what a subscriber receives for each event.
+type Message struct {
+	TraceID string
+	Time    time.Time
+	Event   Event
+}
+
+// =============================================================================
+
+// Subscription represents a subscriber receiving events from the bus.
+type Subscription struct {
+	name    string
+	ch      chan Message
+	dropped atomic.Uint64
+}
+
+// C returns the channel the events are received on. The channel is closed
+// when the subscription is removed or the bus is shut down.
+func (s *Subscription) C() <-chan Message {
+	return s.ch
+}
+
+// Dropped returns the number of events the subscriber missed because it
+// wasn't keeping up.
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// =============================================================================
+
+// Bus delivers the published events to all the subscribers.
+type Bus struct {
+	traceID func(ctx context.Context) string
+
+	mu      sync.RWMutex
+	subs    map[*Subscription]bool
+	dropped atomic.Uint64
+}
+
+// New constructs a bus. The trace id function extracts the trace id from the
+// context of the publisher so each message can be tied to the work that
+// raised it, and can be nil.
+func New(traceID func(ctx context.Context) string) *Bus {
+	return &Bus{
+		traceID: traceID,
+		subs:    make(map[*Subscription]bool),
+	}
+}
+
+// Subscribe adds a subscriber that can hold up to buffer events it hasn't
+// received yet before events are dropped for it.
+func (b *Bus) Subscribe(name string, buffer int) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := Subscription{
+		name: name,
+		ch:   make(chan Message, buffer),
+	}
+	b.subs[&sub] = true
+
+	return &sub
+}
+
+// Unsubscribe removes the subscriber and closes its channel.
+func (b *Bus) Unsubscribe(sub *Subscription) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.subs[sub] {
+		return fmt.Errorf("subscription %q does not exist", sub.name)
+	}
+
+	delete(b.subs, sub)
+	close(sub.ch)
+
+	return nil
+}
+
+// Shutdown removes all the subscribers and closes their channels.
+func (b *Bus) Shutdown() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs {
+		delete(b.subs, sub)
+		close(sub.ch)
+	}
+}
+
+// Publish delivers the event to every subscriber. Publish will not block
+// waiting for a subscriber, it drops the event for the subscriber instead.
+// It's safe to publish to a nil bus.
+func (b *Bus) Publish(ctx context.Context, ev Event) {
+	if b == nil {
+		return
+	}
+
+	msg := Message{
+		Time:  time.Now().UTC(),
+		Event: ev,
+	}
+	if b.traceID != nil {
+		msg.TraceID = b.traceID(ctx)
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subs {
+		select {
+		case sub.ch <- msg:
+		default:
+			sub.dropped.Add(1)
+			b.dropped.Add(1)
+		}
+	}
+}
+
+// Dropped returns the number of events dropped across all the subscribers,
+// including the ones that have been removed.
+func (b *Bus) Dropped() uint64 {
+	return b.dropped.Load()
+}
+
+// =============================================================================
+
+// Log subscribes a logger to the bus that logs every event the same way the
+// node has always logged the state events. Events the logger missed because
+// it fell behind are reported as a count. The returned function waits for
+// the remaining events to be logged once the bus is shut down.
+func Log(b *Bus, log func(traceID string, msg string)) func() {
+
+	// The logger is expected to keep up, but a large buffer covers the
+	// bursts like loading the genesis accounts.
+	const messageBuffer = 1000
+	sub := b.Subscribe("log", messageBuffer)
+
+	var reported uint64
+	reportDropped := func(traceID string) {
+		if dropped := sub.Dropped(); dropped > reported {
+			log(traceID, fmt.Sprintf("bus: log: dropped %d events", dropped-reported))
+			reported = dropped
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for msg := range sub.C() {
+			reportDropped(msg.TraceID)
+			log(msg.TraceID, msg.Event.String())
+		}
+		reportDropped("")
+	}()
+
+	return func() {
+		<-done
+	}
+}
@@ -0,0 +1,75 @@
+package bus_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/bus"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+)
+
+func TestLogDropped(t *testing.T) {
+	b := bus.New(nil)
+
+	// The logger blocks on the first event until released so the rest of
+	// the events back up behind it.
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var lines []string
+	wait := bus.Log(b, func(traceID string, msg string) {
+		if len(lines) == 0 {
+			close(started)
+			<-release
+		}
+		lines = append(lines, msg)
+	})
+
+	b.Publish(context.Background(), bus.ShutdownStarted{})
+	<-started
+
+	const buffer = 1000
+	for i := 0; i < buffer+5; i++ {
+		b.Publish(context.Background(), bus.ShutdownStarted{})
+	}
+	close(release)
+
+	b.Shutdown()
+	wait()
+
+	var dropped string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "bus: log: dropped") {
+			dropped = line
+		}
+	}
+
+	if dropped != "bus: log: dropped 5 events" {
+		t.Errorf("Should log the number of dropped events, got %q", dropped)
+	}
+	if got := b.Dropped(); got != 5 {
+		t.Errorf("Should count the dropped events, got %d", got)
+	}
+}
+
+func TestInvolves(t *testing.T) {
+	const (
+		bill  database.AccountID = "0xF01813E4B85e178A83e29B8E7bF26BD830a25f32"
+		pavel database.AccountID = "0xbEE6ACE826eC3DE1B6349888B9151B92522F7F76"
+	)
+
+	created := bus.AccountCreated{Account: bill}
+
+	if !bus.Involves(created, nil) {
+		t.Errorf("Should match every event without accounts")
+	}
+	if !bus.Involves(created, []database.AccountID{pavel, bill}) {
+		t.Errorf("Should match an event for one of the accounts")
+	}
+	if bus.Involves(created, []database.AccountID{pavel}) {
+		t.Errorf("Should not match an event for other accounts")
+	}
+	if !bus.Involves(bus.ShutdownStarted{}, []database.AccountID{pavel}) {
+		t.Errorf("Should match an event that doesn't relate to accounts")
+	}
+}
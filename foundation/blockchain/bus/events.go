@@ -0,0 +1,96 @@
+package bus
+
+import (
+	"fmt"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+)
+
+// Set of names identifying the events.
+const (
+	NameAccountCreated    = "account_created"
+	NameTxAdmitted        = "tx_admitted"
+	NameTxRejected        = "tx_rejected"
+	NameShutdownStarted   = "shutdown_started"
+	NameShutdownCompleted = "shutdown_completed"
+)
+
+// =============================================================================
+
+// AccountCreated is published when an account is added to the database.
+type AccountCreated struct {
+	Account database.AccountID
+	Balance uint64
+}
+
+// Name implements the Event interface.
+func (AccountCreated) Name() string { return NameAccountCreated }
+
+// Accounts implements the AccountEvent interface.
+func (e AccountCreated) Accounts() []database.AccountID {
+	return []database.AccountID{e.Account}
+}
+
+// String implements the Event interface.
+func (e AccountCreated) String() string {
+	return fmt.Sprintf("Account: %s, Balance: %d", e.Account, e.Balance)
+}
+
+// TxAdmitted is published when a transaction is added to the mempool.
+type TxAdmitted struct {
+	Hash string
+	Tx   database.BlockTx
+}
+
+// Name implements the Event interface.
+func (TxAdmitted) Name() string { return NameTxAdmitted }
+
+// Accounts implements the AccountEvent interface.
+func (e TxAdmitted) Accounts() []database.AccountID {
+	return []database.AccountID{e.Tx.FromID, e.Tx.ToID}
+}
+
+// String implements the Event interface.
+func (e TxAdmitted) String() string {
+	return fmt.Sprintf("state: mempool: added: tx[%s] hash[%s]", e.Tx, e.Hash)
+}
+
+// TxRejected is published when a transaction is refused by the mempool.
+type TxRejected struct {
+	Tx     database.SignedTx
+	Reason string
+	Err    error
+}
+
+// Name implements the Event interface.
+func (TxRejected) Name() string { return NameTxRejected }
+
+// Accounts implements the AccountEvent interface.
+func (e TxRejected) Accounts() []database.AccountID {
+	return []database.AccountID{e.Tx.FromID, e.Tx.ToID}
+}
+
+// String implements the Event interface.
+func (e TxRejected) String() string {
+	return fmt.Sprintf("state: mempool: rejected: tx[%s] reason[%s]: %s", e.Tx, e.Reason, e.Err)
+}
+
+// =============================================================================
+
+// ShutdownStarted is published when the node starts shutting down.
+type ShutdownStarted struct{}
+
+// Name implements the Event interface.
+func (ShutdownStarted) Name() string { return NameShutdownStarted }
+
+// String implements the Event interface.
+func (ShutdownStarted) String() string { return "state: shutdown: started" }
+
+// ShutdownCompleted is published when the node is done shutting down.
+type ShutdownCompleted struct{}
+
+// Name implements the Event interface.
+func (ShutdownCompleted) Name() string { return NameShutdownCompleted }
+
+// String implements the Event interface.
+func (ShutdownCompleted) String() string { return "state: shutdown: completed" }
@@ -16,14 +16,16 @@ type Database struct {
 	mu       sync.RWMutex
 	genesis  genesis.Genesis
 	accounts map[AccountID]Account
+	names    map[string]NameRecord
 }
 
 // New constructs a new database and applies account genesis information and
 // reads/writes the blockchain database on disk if a dbPath is provided.
-func New(genesis genesis.Genesis, evHandler func(v string, args ...any)) (*Database, error) {
+func New(genesis genesis.Genesis) (*Database, error) {
 	db := Database{
 		genesis:  genesis,
 		accounts: make(map[AccountID]Account),
+		names:    make(map[string]NameRecord),
 	}
 
 	// Update the database with account balance information from genesis.
@@ -33,8 +35,6 @@ func New(genesis genesis.Genesis, evHandler func(v string, args ...any)) (*Datab
 			return nil, err
 		}
 		db.accounts[accountID] = newAccount(accountID, balance)
-
-		evHandler("Account: %s, Balance: %d", accountID, balance)
 	}
 
 	return &db, nil
@@ -0,0 +1,236 @@
+package database
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/signature"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MultiSig represents an account that is controlled by a set of owner
+// accounts. A transaction from the account requires signatures from at
+// least threshold number of the owners.
+type MultiSig struct {
+	Owners    []AccountID `json:"owners" validate:"required"`
+	Threshold uint16      `json:"threshold" validate:"required"`
+}
+
+// NewMultiSig constructs a multisig account definition.
+func NewMultiSig(owners []AccountID, threshold uint16) (MultiSig, error) {
+	ms := MultiSig{
+		Owners:    owners,
+		Threshold: threshold,
+	}
+
+	if err := ms.Validate(); err != nil {
+		return MultiSig{}, err
+	}
+
+	return ms, nil
+}
+
+// Validate checks the owners are properly formatted and unique and the
+// threshold can be met.
+func (ms MultiSig) Validate() error {
+	if len(ms.Owners) == 0 {
+		return errors.New("multisig requires at least one owner")
+	}
+
+	if ms.Threshold == 0 || int(ms.Threshold) > len(ms.Owners) {
+		return fmt.Errorf("multisig threshold must be between 1 and %d", len(ms.Owners))
+	}
+
+	seen := make(map[common.Address]bool)
+	for _, owner := range ms.Owners {
+		if !owner.IsAccountID() {
+			return fmt.Errorf("owner account %q is not properly formatted", owner)
+		}
+
+		address := common.HexToAddress(string(owner))
+		if seen[address] {
+			return fmt.Errorf("owner account %s is listed more than once", owner)
+		}
+		seen[address] = true
+	}
+
+	return nil
+}
+
+// AccountID returns the account for the multisig. The account is derived
+// from the owners and the threshold, so the order of the owners doesn't
+// matter and the same definition always produces the same account.
+func (ms MultiSig) AccountID() AccountID {
+	owners := make([]common.Address, len(ms.Owners))
+	for i, owner := range ms.Owners {
+		owners[i] = common.HexToAddress(string(owner))
+	}
+
+	sort.Slice(owners, func(i, j int) bool {
+		return bytes.Compare(owners[i][:], owners[j][:]) < 0
+	})
+
+	data := []byte("\x19Ardan MultiSig:\n")
+	for _, owner := range owners {
+		data = append(data, owner[:]...)
+	}
+	data = append(data, byte(ms.Threshold>>8), byte(ms.Threshold))
+
+	// Like accounts derived from a public key, use the last 20 bytes.
+	return AccountID(common.BytesToAddress(crypto.Keccak256(data)).String())
+}
+
+// isOwner checks if the account is one of the owners.
+func (ms MultiSig) isOwner(accountID AccountID) bool {
+	address := common.HexToAddress(string(accountID))
+	for _, owner := range ms.Owners {
+		if common.HexToAddress(string(owner)) == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+// =============================================================================
+
+// Signature represents a signature in the [R|S|V] format.
+type Signature struct {
+	V *big.Int `json:"v" validate:"required"` // Ethereum: Recovery identifier, either 29 or 30 with ardanID.
+	R *big.Int `json:"r" validate:"required"` // Ethereum: First coordinate of the ECDSA signature.
+	S *big.Int `json:"s" validate:"required"` // Ethereum: Second coordinate of the ECDSA signature.
+}
+
+// Approvals holds the definition of the multisig account sending a
+// transaction along with the signatures of the owners approving it.
+type Approvals struct {
+	MultiSig
+	Signatures []Signature `json:"signatures"`
+}
+
+// ToMultiSigTx constructs a transaction from a multisig account that is
+// waiting for the owners' signatures.
+func (tx Tx) ToMultiSigTx(ms MultiSig) (SignedTx, error) {
+	if err := ms.Validate(); err != nil {
+		return SignedTx{}, err
+	}
+
+	if tx.FromID != ms.AccountID() {
+		return SignedTx{}, fmt.Errorf("from account %s is not the multisig account %s", tx.FromID, ms.AccountID())
+	}
+
+	signedTx := SignedTx{
+		Tx:        tx,
+		Approvals: &Approvals{MultiSig: ms},
+	}
+
+	return signedTx, nil
+}
+
+// Approve uses the specified private key of one of the owners to add a
+// signature to a transaction from a multisig account.
+func (tx SignedTx) Approve(privateKey *ecdsa.PrivateKey) (SignedTx, error) {
+	if tx.Approvals == nil {
+		return SignedTx{}, errors.New("transaction is not from a multisig account")
+	}
+
+	accountID := PublicKeyToAccountID(privateKey.PublicKey)
+	if !tx.Approvals.isOwner(accountID) {
+		return SignedTx{}, fmt.Errorf("account %s is not an owner of the multisig account", accountID)
+	}
+
+	approvers, err := tx.Approvers()
+	if err != nil {
+		return SignedTx{}, err
+	}
+
+	for _, approver := range approvers {
+		if approver == accountID {
+			return SignedTx{}, fmt.Errorf("account %s already approved the transaction", accountID)
+		}
+	}
+
+	v, r, s, err := signature.Sign(tx.Tx, privateKey)
+	if err != nil {
+		return SignedTx{}, err
+	}
+
+	// Copy the approvals so the original transaction isn't modified.
+	approvals := Approvals{
+		MultiSig:   tx.Approvals.MultiSig,
+		Signatures: append(append([]Signature{}, tx.Approvals.Signatures...), Signature{V: v, R: r, S: s}),
+	}
+	tx.Approvals = &approvals
+
+	return tx, nil
+}
+
+// Approvers returns the owners who have signed the transaction.
+func (tx SignedTx) Approvers() ([]AccountID, error) {
+	if tx.Approvals == nil {
+		return nil, errors.New("transaction is not from a multisig account")
+	}
+
+	approvers := make([]AccountID, 0, len(tx.Approvals.Signatures))
+	seen := make(map[AccountID]bool)
+	for _, sig := range tx.Approvals.Signatures {
+		if sig.V == nil || sig.R == nil || sig.S == nil {
+			return nil, errors.New("multisig signature is incomplete")
+		}
+
+		if err := signature.VerifySignature(sig.V, sig.R, sig.S); err != nil {
+			return nil, err
+		}
+
+		address, err := signature.ExtractAddress(tx.Tx, sig.V, sig.R, sig.S)
+		if err != nil {
+			return nil, err
+		}
+
+		accountID := AccountID(address)
+		if !tx.Approvals.isOwner(accountID) {
+			return nil, fmt.Errorf("signature from %s who is not an owner of the multisig account", accountID)
+		}
+
+		if seen[accountID] {
+			return nil, fmt.Errorf("account %s signed the transaction more than once", accountID)
+		}
+		seen[accountID] = true
+
+		approvers = append(approvers, accountID)
+	}
+
+	return approvers, nil
+}
+
+// validateApprovals verifies the transaction is from the multisig account and
+// has been signed by enough of the owners.
+func (tx SignedTx) validateApprovals() error {
+	if tx.V != nil || tx.R != nil || tx.S != nil {
+		return errors.New("multisig transaction can't carry a single signature")
+	}
+
+	if err := tx.Approvals.Validate(); err != nil {
+		return err
+	}
+
+	if tx.FromID != tx.Approvals.AccountID() {
+		return errors.New("from account doesn't match the multisig account")
+	}
+
+	approvers, err := tx.Approvers()
+	if err != nil {
+		return err
+	}
+
+	if len(approvers) < int(tx.Approvals.Threshold) {
+		return fmt.Errorf("multisig transaction has %d of %d required signatures", len(approvers), tx.Approvals.Threshold)
+	}
+
+	return nil
+}
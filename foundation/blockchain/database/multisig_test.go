@@ -0,0 +1,169 @@
+package database_test
+
+import (
+	"crypto/ecdsa"
+	"strings"
+	"testing"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/signature"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// newKeys generates n private keys along with their accounts.
+func newKeys(t *testing.T, n int) ([]*ecdsa.PrivateKey, []database.AccountID) {
+	keys := make([]*ecdsa.PrivateKey, n)
+	accounts := make([]database.AccountID, n)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatalf("Should be able to generate a private key: %s", err)
+		}
+		keys[i] = key
+		accounts[i] = database.PublicKeyToAccountID(key.PublicKey)
+	}
+
+	return keys, accounts
+}
+
+func TestMultiSigAccountID(t *testing.T) {
+	_, owners := newKeys(t, 3)
+
+	ms, err := database.NewMultiSig(owners, 2)
+	if err != nil {
+		t.Fatalf("Should be able to construct the multisig: %s", err)
+	}
+
+	reordered, err := database.NewMultiSig([]database.AccountID{owners[2], owners[0], owners[1]}, 2)
+	if err != nil {
+		t.Fatalf("Should be able to construct the reordered multisig: %s", err)
+	}
+
+	if ms.AccountID() != reordered.AccountID() {
+		t.Errorf("Should get the same account when the owners are reordered, got %s and %s", ms.AccountID(), reordered.AccountID())
+	}
+
+	if !ms.AccountID().IsAccountID() {
+		t.Errorf("Should get a properly formatted account, got %s", ms.AccountID())
+	}
+
+	threshold, err := database.NewMultiSig(owners, 3)
+	if err != nil {
+		t.Fatalf("Should be able to construct the multisig: %s", err)
+	}
+
+	if ms.AccountID() == threshold.AccountID() {
+		t.Errorf("Should get a different account for a different threshold")
+	}
+}
+
+func TestMultiSigValidate(t *testing.T) {
+	keys, owners := newKeys(t, 3)
+	outsiders, _ := newKeys(t, 1)
+
+	ms, err := database.NewMultiSig(owners, 2)
+	if err != nil {
+		t.Fatalf("Should be able to construct the multisig: %s", err)
+	}
+
+	tx, err := database.NewTx(1, 1, ms.AccountID(), pavel, 10, 0, nil)
+	if err != nil {
+		t.Fatalf("Should be able to construct the transaction: %s", err)
+	}
+
+	unsigned, err := tx.ToMultiSigTx(ms)
+	if err != nil {
+		t.Fatalf("Should be able to construct the multisig transaction: %s", err)
+	}
+
+	// approve adds the owners' signatures to the transaction.
+	approve := func(t *testing.T, signedTx database.SignedTx, keys ...*ecdsa.PrivateKey) database.SignedTx {
+		for _, key := range keys {
+			var err error
+			if signedTx, err = signedTx.Approve(key); err != nil {
+				t.Fatalf("Should be able to approve the transaction: %s", err)
+			}
+		}
+		return signedTx
+	}
+
+	// sign returns a signature over the transaction from any key.
+	sign := func(t *testing.T, key *ecdsa.PrivateKey) database.Signature {
+		v, r, s, err := signature.Sign(tx, key)
+		if err != nil {
+			t.Fatalf("Should be able to sign the transaction: %s", err)
+		}
+		return database.Signature{V: v, R: r, S: s}
+	}
+
+	t.Run("threshold met", func(t *testing.T) {
+		if err := approve(t, unsigned, keys[0], keys[2]).Validate(1); err != nil {
+			t.Errorf("Should accept a transaction signed by enough owners: %s", err)
+		}
+	})
+
+	t.Run("below threshold", func(t *testing.T) {
+		if err := approve(t, unsigned, keys[1]).Validate(1); err == nil {
+			t.Errorf("Should reject a transaction with fewer signatures than the threshold")
+		}
+	})
+
+	t.Run("duplicate owner signature", func(t *testing.T) {
+		signedTx := approve(t, unsigned, keys[0])
+		signedTx.Approvals.Signatures = append(signedTx.Approvals.Signatures, signedTx.Approvals.Signatures[0])
+
+		if err := signedTx.Validate(1); err == nil {
+			t.Errorf("Should reject the same owner signing twice")
+		}
+
+		if _, err := approve(t, unsigned, keys[0]).Approve(keys[0]); err == nil {
+			t.Errorf("Should not let an owner approve twice")
+		}
+	})
+
+	t.Run("signer not an owner", func(t *testing.T) {
+		signedTx := approve(t, unsigned, keys[0])
+		signedTx.Approvals.Signatures = append(signedTx.Approvals.Signatures, sign(t, outsiders[0]))
+
+		if err := signedTx.Validate(1); err == nil {
+			t.Errorf("Should reject a signature from an account that isn't an owner")
+		}
+
+		if _, err := unsigned.Approve(outsiders[0]); err == nil {
+			t.Errorf("Should not let an account that isn't an owner approve")
+		}
+	})
+
+	t.Run("from not the multisig account", func(t *testing.T) {
+
+		// The same owners with a threshold of one make a different account,
+		// so the signatures are good but the from account doesn't match.
+		one, err := database.NewMultiSig(owners, 1)
+		if err != nil {
+			t.Fatalf("Should be able to construct the multisig: %s", err)
+		}
+
+		signedTx := approve(t, unsigned, keys[0], keys[1])
+		signedTx.Approvals.MultiSig = one
+
+		if err := signedTx.Validate(1); err == nil || !strings.Contains(err.Error(), "doesn't match") {
+			t.Errorf("Should reject a transaction whose from account isn't the multisig account, got %v", err)
+		}
+
+		other := tx
+		other.FromID = bill
+		if _, err := other.ToMultiSigTx(ms); err == nil {
+			t.Errorf("Should not build a multisig transaction from another account")
+		}
+	})
+
+	t.Run("single signature", func(t *testing.T) {
+		signedTx := approve(t, unsigned, keys[0], keys[1])
+		sig := sign(t, keys[0])
+		signedTx.V, signedTx.R, signedTx.S = sig.V, sig.R, sig.S
+
+		if err := signedTx.Validate(1); err == nil {
+			t.Errorf("Should reject a multisig transaction that also carries a single signature")
+		}
+	})
+}
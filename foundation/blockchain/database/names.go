@@ -0,0 +1,159 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Set of operations a transaction can perform on the name registry.
+const (
+	NameRegister = "register"
+	NameTransfer = "transfer"
+)
+
+// nameFormat is the format of a registered name. Names start with a letter
+// so they can never be confused with an account.
+var nameFormat = regexp.MustCompile(`^[a-z][a-z0-9-]{2,31}$`)
+
+// NameOp represents an operation on the name registry carried in the data
+// field of a transaction. A register assigns an unowned name to the to
+// account and a transfer moves a name owned by the from account to the to
+// account.
+type NameOp struct {
+	Op   string `json:"op"`
+	Name string `json:"name"`
+}
+
+// NewNameOp constructs the data for a transaction performing the operation.
+func NewNameOp(op string, name string) ([]byte, error) {
+	nameOp := NameOp{
+		Op:   op,
+		Name: name,
+	}
+
+	if err := nameOp.validate(); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(nameOp)
+}
+
+// ParseNameOp returns the name operation carried in the transaction data. It
+// reports false when the data is not a name operation.
+func ParseNameOp(data []byte) (NameOp, bool, error) {
+	if len(data) == 0 || data[0] != '{' {
+		return NameOp{}, false, nil
+	}
+
+	var nameOp NameOp
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&nameOp); err != nil || nameOp.Op == "" {
+		return NameOp{}, false, nil
+	}
+
+	if err := nameOp.validate(); err != nil {
+		return NameOp{}, true, err
+	}
+
+	return nameOp, true, nil
+}
+
+// validate checks the operation is known and the name is well formed.
+func (no NameOp) validate() error {
+	switch no.Op {
+	case NameRegister, NameTransfer:
+	default:
+		return fmt.Errorf("unknown name operation %q", no.Op)
+	}
+
+	if !nameFormat.MatchString(no.Name) {
+		return fmt.Errorf("name %q must be 3 to 32 lowercase letters, digits or dashes starting with a letter", no.Name)
+	}
+
+	return nil
+}
+
+// =============================================================================
+
+// NameRecord represents the ownership of a name in the registry.
+type NameRecord struct {
+	Name  string    `json:"name"`
+	Owner AccountID `json:"owner"`
+}
+
+// CheckNameOp verifies the operation can be performed by the transaction
+// against the current registry. A name can only be registered when no one
+// owns it, only the owner can transfer it, and the from account must be able
+// to pay the name fee.
+func (db *Database) CheckNameOp(tx Tx, nameOp NameOp) error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	record, owned := db.names[nameOp.Name]
+
+	switch nameOp.Op {
+	case NameRegister:
+		if owned {
+			return fmt.Errorf("name %q is already registered to %s", nameOp.Name, record.Owner)
+		}
+
+	case NameTransfer:
+		if !owned {
+			return fmt.Errorf("name %q is not registered", nameOp.Name)
+		}
+		if record.Owner != checksum(tx.FromID) {
+			return fmt.Errorf("name %q is not owned by %s", nameOp.Name, tx.FromID)
+		}
+		if record.Owner == checksum(tx.ToID) {
+			return fmt.Errorf("name %q is already owned by %s", nameOp.Name, tx.ToID)
+		}
+	}
+
+	if balance, fee := db.accounts[tx.FromID].Balance, db.genesis.NameFee; balance < fee {
+		return fmt.Errorf("insufficient balance %d for the name fee %d", balance, fee)
+	}
+
+	return nil
+}
+
+// QueryName retrieves the ownership of a name from the registry.
+func (db *Database) QueryName(name string) (NameRecord, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	record, exists := db.names[name]
+	if !exists {
+		return NameRecord{}, errors.New("name is not registered")
+	}
+
+	return record, nil
+}
+
+// QueryOwnedNames returns the names owned by the account in sorted order.
+func (db *Database) QueryOwnedNames(accountID AccountID) []string {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	var names []string
+	for name, record := range db.names {
+		if record.Owner == checksum(accountID) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// checksum returns the account in its checksummed form so the owners of names
+// compare the same whatever case the transaction used.
+func checksum(accountID AccountID) AccountID {
+	return AccountID(common.HexToAddress(string(accountID)).Hex())
+}
@@ -0,0 +1,78 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/genesis"
+)
+
+const (
+	bill  database.AccountID = "0xF01813E4B85e178A83e29B8E7bF26BD830a25f32"
+	pavel database.AccountID = "0xbEE6ACE826eC3DE1B6349888B9151B92522F7F76"
+	poor  database.AccountID = "0x6Fe6CF3c8fF57c58d24BfC869668F48BCbDb3BD9"
+)
+
+func newDatabase(t *testing.T) *database.Database {
+	db, err := database.New(genesis.Genesis{
+		ChainID: 1,
+		NameFee: 100,
+		Balances: map[string]uint64{
+			string(bill):  1000,
+			string(pavel): 1000,
+			string(poor):  50,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Should be able to construct the database: %s", err)
+	}
+
+	return db
+}
+
+func TestCheckNameOp(t *testing.T) {
+	db := newDatabase(t)
+
+	register := database.NameOp{Op: database.NameRegister, Name: "bill"}
+	if err := db.CheckNameOp(database.Tx{FromID: bill, ToID: pavel}, register); err != nil {
+		t.Errorf("Should be able to register an unowned name: %s", err)
+	}
+
+	transfer := database.NameOp{Op: database.NameTransfer, Name: "bill"}
+	if err := db.CheckNameOp(database.Tx{FromID: bill, ToID: pavel}, transfer); err == nil {
+		t.Errorf("Should not transfer a name that isn't registered")
+	}
+}
+
+func TestCheckNameOpFee(t *testing.T) {
+	db := newDatabase(t)
+
+	register := database.NameOp{Op: database.NameRegister, Name: "poor"}
+	if err := db.CheckNameOp(database.Tx{FromID: poor, ToID: bill}, register); err == nil {
+		t.Errorf("Should reject an account that can't pay the name fee")
+	}
+
+	if err := db.CheckNameOp(database.Tx{FromID: bill, ToID: poor}, register); err != nil {
+		t.Errorf("Should accept an account that can pay the name fee: %s", err)
+	}
+}
+
+func TestParseNameOp(t *testing.T) {
+	data, err := database.NewNameOp(database.NameRegister, "kennedy")
+	if err != nil {
+		t.Fatalf("Should be able to construct the name operation: %s", err)
+	}
+
+	nameOp, ok, err := database.ParseNameOp(data)
+	if err != nil || !ok || nameOp.Name != "kennedy" || nameOp.Op != database.NameRegister {
+		t.Errorf("Should parse the name operation back, got %+v %v %v", nameOp, ok, err)
+	}
+
+	if _, ok, _ := database.ParseNameOp([]byte("hello")); ok {
+		t.Errorf("Should not treat other data as a name operation")
+	}
+
+	if _, ok, err := database.ParseNameOp([]byte(`{"op":"register","name":"1bad"}`)); !ok || err == nil {
+		t.Errorf("Should reject a malformed name, got %v %v", ok, err)
+	}
+}
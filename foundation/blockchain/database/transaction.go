@@ -1,28 +1,34 @@
 package database
 
 import (
-	"bytes"
 	"crypto/ecdsa"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/signature"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // =============================================================================
 
 // Tx is the transactional information between two parties.
+//
+// A nonce of zero is rejected by the required rule on purpose. An account
+// starts with a nonce of zero and its first transaction uses nonce one, so
+// zero is never a valid transaction nonce.
 type Tx struct {
-	ChainID uint16    `json:"chain_id"` // Ethereum: The chain id that is listed in the genesis file.
-	Nonce   uint64    `json:"nonce"`    // Ethereum: Unique id for the transaction supplied by the user.
-	FromID  AccountID `json:"from"`     // Ethereum: Account sending the transaction. Will be checked against signature.
-	ToID    AccountID `json:"to"`       // Ethereum: Account receiving the benefit of the transaction.
-	Value   uint64    `json:"value"`    // Ethereum: Monetary value received from this transaction.
-	Tip     uint64    `json:"tip"`      // Ethereum: Tip offered by the sender as an incentive to mine this transaction.
-	Data    []byte    `json:"data"`     // Ethereum: Extra data related to the transaction.
+	ChainID uint16    `json:"chain_id" validate:"required"`        // Ethereum: The chain id that is listed in the genesis file.
+	Nonce   uint64    `json:"nonce" validate:"required"`           // Ethereum: Unique id for the transaction supplied by the user.
+	FromID  AccountID `json:"from" validate:"required,hexaccount"` // Ethereum: Account sending the transaction. Will be checked against signature.
+	ToID    AccountID `json:"to" validate:"required,hexaccount"`   // Ethereum: Account receiving the benefit of the transaction.
+	Value   uint64    `json:"value"`                               // Ethereum: Monetary value received from this transaction.
+	Tip     uint64    `json:"tip"`                                 // Ethereum: Tip offered by the sender as an incentive to mine this transaction.
+	Data    []byte    `json:"data"`                                // Ethereum: Extra data related to the transaction.
 }
 
 // NewTx constructs a new transaction.
@@ -71,17 +77,21 @@ func (tx Tx) Sign(privateKey *ecdsa.PrivateKey) (SignedTx, error) {
 // =============================================================================
 
 // SignedTx is a signed version of the transaction. This is how clients like
-// a wallet provide transactions for inclusion into the blockchain.
+// a wallet provide transactions for inclusion into the blockchain. A
+// transaction from a multisig account carries the owners' signatures in
+// the approvals instead of a single signature.
 type SignedTx struct {
 	Tx
-	V *big.Int `json:"v"` // Ethereum: Recovery identifier, either 29 or 30 with ardanID.
-	R *big.Int `json:"r"` // Ethereum: First coordinate of the ECDSA signature.
-	S *big.Int `json:"s"` // Ethereum: Second coordinate of the ECDSA signature.
+	V         *big.Int   `json:"v" validate:"required_without=Approvals"` // Ethereum: Recovery identifier, either 29 or 30 with ardanID.
+	R         *big.Int   `json:"r" validate:"required_without=Approvals"` // Ethereum: First coordinate of the ECDSA signature.
+	S         *big.Int   `json:"s" validate:"required_without=Approvals"` // Ethereum: Second coordinate of the ECDSA signature.
+	Approvals *Approvals `json:"approvals,omitempty"`                     // Multisig account definition and the owners' signatures.
 }
 
 // Validate verifies the transaction has a proper signature that conforms to our
 // standards. It also checks the from field matches the account that signed the
-// transaction. Last it checks the format of the from and to fields.
+// transaction, or for a multisig account that enough owners signed it. Last it
+// checks the format of the from and to fields.
 func (tx SignedTx) Validate(chainID uint16) error {
 	if tx.ChainID != chainID {
 		return fmt.Errorf("invalid chain id, got[%d] exp[%d]", tx.ChainID, chainID)
@@ -99,6 +109,14 @@ func (tx SignedTx) Validate(chainID uint16) error {
 		return fmt.Errorf("transaction invalid, sending money to yourself, from %s, to %s", tx.FromID, tx.ToID)
 	}
 
+	if tx.Approvals != nil {
+		return tx.validateApprovals()
+	}
+
+	if tx.V == nil || tx.R == nil || tx.S == nil {
+		return errors.New("transaction is not signed")
+	}
+
 	if err := signature.VerifySignature(tx.V, tx.R, tx.S); err != nil {
 		return err
 	}
@@ -115,8 +133,52 @@ func (tx SignedTx) Validate(chainID uint16) error {
 	return nil
 }
 
-// SignatureString returns the signature as a string.
+// TxHash returns the canonical hash of the signed transaction. This is the
+// identifier used to look up the transaction. Signing is deterministic and
+// only the lower S value is accepted, so a transaction has one signature.
+// The approvals of a multisig transaction are put in order first since the
+// owners and signatures can be listed in any order.
+func (tx SignedTx) TxHash() string {
+	if tx.Approvals != nil {
+		owners := make([]AccountID, len(tx.Approvals.Owners))
+		for i, owner := range tx.Approvals.Owners {
+			owners[i] = AccountID(common.HexToAddress(string(owner)).String())
+		}
+		sort.Slice(owners, func(i, j int) bool { return owners[i] < owners[j] })
+
+		sigs := make([]Signature, len(tx.Approvals.Signatures))
+		copy(sigs, tx.Approvals.Signatures)
+		sort.Slice(sigs, func(i, j int) bool {
+			if sigs[i].R == nil || sigs[j].R == nil {
+				return sigs[j].R != nil
+			}
+			return sigs[i].R.Cmp(sigs[j].R) < 0
+		})
+
+		tx.Approvals = &Approvals{
+			MultiSig:   MultiSig{Owners: owners, Threshold: tx.Approvals.Threshold},
+			Signatures: sigs,
+		}
+	}
+
+	return signature.Hash(tx)
+}
+
+// SignatureString returns the signature as a string. The signatures of a
+// multisig transaction are separated by commas.
 func (tx SignedTx) SignatureString() string {
+	if tx.Approvals != nil {
+		sigs := make([]string, len(tx.Approvals.Signatures))
+		for i, sig := range tx.Approvals.Signatures {
+			sigs[i] = signature.SignatureString(sig.V, sig.R, sig.S)
+		}
+		return strings.Join(sigs, ",")
+	}
+
+	if tx.V == nil || tx.R == nil || tx.S == nil {
+		return ""
+	}
+
 	return signature.SignatureString(tx.V, tx.R, tx.S)
 }
 
@@ -159,8 +221,5 @@ func (tx BlockTx) Hash() ([]byte, error) {
 // check between two block transactions. If the nonce and signatures are the
 // same, the two blocks are the same.
 func (tx BlockTx) Equals(otherTx BlockTx) bool {
-	txSig := signature.ToSignatureBytes(tx.V, tx.R, tx.S)
-	otherTxSig := signature.ToSignatureBytes(otherTx.V, otherTx.R, otherTx.S)
-
-	return tx.Nonce == otherTx.Nonce && bytes.Equal(txSig, otherTxSig)
+	return tx.Nonce == otherTx.Nonce && tx.SignatureString() == otherTx.SignatureString()
 }
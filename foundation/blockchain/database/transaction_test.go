@@ -0,0 +1,96 @@
+package database_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestTxHashFlippedS(t *testing.T) {
+	keys, accounts := newKeys(t, 1)
+
+	tx, err := database.NewTx(1, 1, accounts[0], pavel, 10, 0, nil)
+	if err != nil {
+		t.Fatalf("Should be able to construct the transaction: %s", err)
+	}
+
+	signedTx, err := tx.Sign(keys[0])
+	if err != nil {
+		t.Fatalf("Should be able to sign the transaction: %s", err)
+	}
+
+	if err := signedTx.Validate(1); err != nil {
+		t.Fatalf("Should accept the signed transaction: %s", err)
+	}
+
+	// Flip S to the other valid value and the recovery id with it.
+	flipped := signedTx
+	flipped.S = new(big.Int).Sub(crypto.S256().Params().N, signedTx.S)
+	flipped.V = new(big.Int).Sub(big.NewInt(59), signedTx.V)
+
+	if err := flipped.Validate(1); err == nil {
+		t.Errorf("Should reject the transaction with the flipped signature")
+	}
+
+	again, err := tx.Sign(keys[0])
+	if err != nil {
+		t.Fatalf("Should be able to sign the transaction again: %s", err)
+	}
+
+	if again.TxHash() != signedTx.TxHash() {
+		t.Errorf("Should get the same hash when the transaction is signed again")
+	}
+}
+
+func TestTxHashMultiSig(t *testing.T) {
+	keys, owners := newKeys(t, 3)
+
+	ms, err := database.NewMultiSig(owners, 2)
+	if err != nil {
+		t.Fatalf("Should be able to construct the multisig: %s", err)
+	}
+
+	tx, err := database.NewTx(1, 1, ms.AccountID(), pavel, 10, 0, nil)
+	if err != nil {
+		t.Fatalf("Should be able to construct the transaction: %s", err)
+	}
+
+	signedTx, err := tx.ToMultiSigTx(ms)
+	if err != nil {
+		t.Fatalf("Should be able to construct the multisig transaction: %s", err)
+	}
+	for _, key := range keys[:2] {
+		if signedTx, err = signedTx.Approve(key); err != nil {
+			t.Fatalf("Should be able to approve the transaction: %s", err)
+		}
+	}
+
+	// Anyone relaying the transaction can reorder the signatures and the
+	// owners, or change the case of the owner accounts.
+	sigs := signedTx.Approvals.Signatures
+	reordered := signedTx
+	reordered.Approvals = &database.Approvals{
+		MultiSig: database.MultiSig{
+			Owners:    []database.AccountID{owners[2], database.AccountID(strings.ToLower(string(owners[0]))), owners[1]},
+			Threshold: 2,
+		},
+		Signatures: []database.Signature{sigs[1], sigs[0]},
+	}
+
+	if err := reordered.Validate(1); err != nil {
+		t.Fatalf("Should accept the reordered approvals: %s", err)
+	}
+
+	if reordered.TxHash() != signedTx.TxHash() {
+		t.Errorf("Should get the same hash when the approvals are reordered")
+	}
+
+	changed := signedTx
+	changed.Value = 11
+	if changed.TxHash() == signedTx.TxHash() {
+		t.Errorf("Should get a different hash for a different transaction")
+	}
+}
@@ -15,6 +15,7 @@ type Genesis struct {
 	Difficulty    uint16            `json:"difficulty"`      // How difficult it needs to be to solve the work problem.
 	MiningReward  uint64            `json:"mining_reward"`   // Reward for mining a block.
 	GasPrice      uint64            `json:"gas_price"`       // Fee paid for each transaction mined into a block.
+	NameFee       uint64            `json:"name_fee"`        // Fee paid to register or transfer a name.
 	Balances      map[string]uint64 `json:"balances"`
 }
 
@@ -36,3 +37,13 @@ func Load() (Genesis, error) {
 
 	return genesis, nil
 }
+
+// Save writes the genesis information to the specified file.
+func Save(path string, genesis Genesis) error {
+	content, err := json.MarshalIndent(genesis, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(content, '\n'), 0644)
+}
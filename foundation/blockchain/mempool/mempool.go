@@ -13,9 +13,11 @@ import (
 )
 
 // Mempool represents a cache of transactions organized by account:nonce.
+// The transactions are also indexed by their hash for lookups.
 type Mempool struct {
-	mu   sync.RWMutex
-	pool map[string]database.BlockTx
+	mu     sync.RWMutex
+	pool   map[string]database.BlockTx
+	hashes map[string]string
 
 	selectFn selector.Func
 }
@@ -34,6 +36,7 @@ func NewWithStrategy(strategy string) (*Mempool, error) {
 
 	mp := Mempool{
 		pool:     make(map[string]database.BlockTx),
+		hashes:   make(map[string]string),
 		selectFn: selectFn,
 	}
 
@@ -72,9 +75,11 @@ func (mp *Mempool) Upsert(tx database.BlockTx) error {
 		if tx.Tip < uint64(math.Round(float64(etx.Tip)*1.10)) {
 			return errors.New("replacing a transaction requires a 10% bump in the tip")
 		}
+		delete(mp.hashes, etx.TxHash())
 	}
 
 	mp.pool[key] = tx
+	mp.hashes[tx.TxHash()] = key
 
 	return nil
 }
@@ -89,17 +94,47 @@ func (mp *Mempool) Delete(tx database.BlockTx) error {
 		return err
 	}
 
+	if etx, exists := mp.pool[key]; exists {
+		delete(mp.hashes, etx.TxHash())
+	}
 	delete(mp.pool, key)
 
 	return nil
 }
 
+// Query returns the transaction for the account and nonce from the mempool.
+func (mp *Mempool) Query(accountID database.AccountID, nonce uint64) (database.BlockTx, error) {
+	mp.mu.RLock()
+	defer mp.mu.RUnlock()
+
+	tx, exists := mp.pool[accountKey(accountID, nonce)]
+	if !exists {
+		return database.BlockTx{}, errors.New("transaction not found in mempool")
+	}
+
+	return tx, nil
+}
+
+// QueryHash returns the transaction with the specified hash from the mempool.
+func (mp *Mempool) QueryHash(hash string) (database.BlockTx, error) {
+	mp.mu.RLock()
+	defer mp.mu.RUnlock()
+
+	key, exists := mp.hashes[hash]
+	if !exists {
+		return database.BlockTx{}, errors.New("transaction not found in mempool")
+	}
+
+	return mp.pool[key], nil
+}
+
 // Truncate clears all the transactions from the pool.
 func (mp *Mempool) Truncate() {
 	mp.mu.Lock()
 	defer mp.mu.Unlock()
 
 	mp.pool = make(map[string]database.BlockTx)
+	mp.hashes = make(map[string]string)
 }
 
 // PickBest uses the configured sort strategy to return a set of transactions.
@@ -144,7 +179,13 @@ func (mp *Mempool) PickBest(howMany ...uint16) []database.BlockTx {
 
 // mapKey is used to generate the map key.
 func mapKey(tx database.BlockTx) (string, error) {
-	return fmt.Sprintf("%s:%d", tx.FromID, tx.Nonce), nil
+	return accountKey(tx.FromID, tx.Nonce), nil
+}
+
+// accountKey generates the map key for the account's transaction with the
+// specified nonce.
+func accountKey(accountID database.AccountID, nonce uint64) string {
+	return fmt.Sprintf("%s:%d", accountID, nonce)
 }
 
 // accountFromMapKey extracts the account information from the mapkey.
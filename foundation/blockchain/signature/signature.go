@@ -81,8 +81,10 @@ func VerifySignature(v, r, s *big.Int) error {
 		return errors.New("invalid recovery id")
 	}
 
-	// Check the signature values are valid.
-	if !crypto.ValidateSignatureValues(byte(uintV), r, s, false) {
+	// Check the signature values are valid. Only the lower S value is
+	// accepted, otherwise anyone could flip S to produce a second valid
+	// signature and change the transaction hash.
+	if !crypto.ValidateSignatureValues(byte(uintV), r, s, true) {
 		return errors.New("invalid signature values")
 	}
 
@@ -111,6 +113,30 @@ func ExtractAddress(value any, v, r, s *big.Int) (string, error) {
 	return crypto.PubkeyToAddress(*publicKey).String(), nil
 }
 
+// VerifyMessage verifies the hex encoded signature, as produced by
+// SignatureString, was created by signing the value and returns the address
+// for the account that signed it.
+func VerifyMessage(value any, sigStr string) (string, error) {
+	sig, err := hexutil.Decode(sigStr)
+	if err != nil {
+		return "", fmt.Errorf("invalid signature encoding: %w", err)
+	}
+
+	if len(sig) != crypto.SignatureLength {
+		return "", fmt.Errorf("invalid signature length, got[%d] exp[%d]", len(sig), crypto.SignatureLength)
+	}
+
+	r := big.NewInt(0).SetBytes(sig[:32])
+	s := big.NewInt(0).SetBytes(sig[32:64])
+	v := big.NewInt(0).SetBytes([]byte{sig[64]})
+
+	if err := VerifySignature(v, r, s); err != nil {
+		return "", err
+	}
+
+	return ExtractAddress(value, v, r, s)
+}
+
 // SignatureString returns the signature as a string.
 func SignatureString(v, r, s *big.Int) string {
 	return hexutil.Encode(ToSignatureBytesWithArdanID(v, r, s))
@@ -0,0 +1,88 @@
+package signature_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/signature"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestVerifyMessage(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Should be able to generate a private key: %s", err)
+	}
+	address := crypto.PubkeyToAddress(privateKey.PublicKey).String()
+
+	const message = "I own this account"
+
+	v, r, s, err := signature.Sign(message, privateKey)
+	if err != nil {
+		t.Fatalf("Should be able to sign the message: %s", err)
+	}
+	sig := signature.SignatureString(v, r, s)
+
+	signer, err := signature.VerifyMessage(message, sig)
+	if err != nil {
+		t.Fatalf("Should be able to verify the message: %s", err)
+	}
+	if signer != address {
+		t.Errorf("Should get the account that signed the message, got %s, exp %s", signer, address)
+	}
+
+	signer, err = signature.VerifyMessage("I own another account", sig)
+	if err == nil && signer == address {
+		t.Errorf("Should not get the signer for a different message")
+	}
+
+	tt := []struct {
+		name string
+		sig  string
+	}{
+		{"not hex", "0xzz"},
+		{"no prefix", sig[2:]},
+		{"too short", sig[:len(sig)-2]},
+		{"too long", sig + "00"},
+		{"bad recovery id", sig[:len(sig)-2] + "05"},
+	}
+
+	for _, tst := range tt {
+		t.Run(tst.name, func(t *testing.T) {
+			if _, err := signature.VerifyMessage(message, tst.sig); err == nil {
+				t.Errorf("Should reject the signature")
+			}
+		})
+	}
+}
+
+func TestVerifySignatureHighS(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Should be able to generate a private key: %s", err)
+	}
+
+	v, r, s, err := signature.Sign("hello", privateKey)
+	if err != nil {
+		t.Fatalf("Should be able to sign the value: %s", err)
+	}
+
+	if err := signature.VerifySignature(v, r, s); err != nil {
+		t.Fatalf("Should accept the signature: %s", err)
+	}
+
+	// The other S value with the recovery id flipped is a second valid
+	// ECDSA signature for the same key and data.
+	n := crypto.S256().Params().N
+	highS := new(big.Int).Sub(n, s)
+	flippedV := new(big.Int).Sub(big.NewInt(59), v) // 29 <-> 30
+
+	address, err := signature.ExtractAddress("hello", flippedV, r, highS)
+	if err != nil || address != crypto.PubkeyToAddress(privateKey.PublicKey).String() {
+		t.Fatalf("Should recover the signer from the flipped signature, got %s %v", address, err)
+	}
+
+	if err := signature.VerifySignature(flippedV, r, highS); err == nil {
+		t.Errorf("Should reject the signature with the higher S value")
+	}
+}
@@ -11,3 +11,26 @@ const QueryLastest = ^uint64(0) >> 1
 func (s *State) QueryAccount(account database.AccountID) (database.Account, error) {
 	return s.db.Query(account)
 }
+
+// QueryMempoolTx returns the transaction for the account and nonce if it is
+// waiting in the mempool.
+func (s *State) QueryMempoolTx(account database.AccountID, nonce uint64) (database.BlockTx, error) {
+	return s.mempool.Query(account, nonce)
+}
+
+// QueryMempoolHash returns the transaction with the specified hash if it is
+// waiting in the mempool.
+func (s *State) QueryMempoolHash(hash string) (database.BlockTx, error) {
+	return s.mempool.QueryHash(hash)
+}
+
+// QueryName returns the ownership of the name from the on-chain registry.
+func (s *State) QueryName(name string) (database.NameRecord, error) {
+	return s.db.QueryName(name)
+}
+
+// QueryOwnedNames returns the names the account owns in the on-chain
+// registry.
+func (s *State) QueryOwnedNames(accountID database.AccountID) []string {
+	return s.db.QueryOwnedNames(accountID)
+}
@@ -3,8 +3,10 @@
 package state
 
 import (
+	"context"
 	"sync"
 
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/bus"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/genesis"
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/mempool"
@@ -12,16 +14,12 @@ import (
 
 // =============================================================================
 
-// EventHandler defines a function that is called when events
-// occur in the processing of persisting blocks.
-type EventHandler func(v string, args ...any)
-
 // Config represents the configuration required to start
 // the blockchain node.
 type Config struct {
 	BeneficiaryID  database.AccountID
 	Genesis        genesis.Genesis
-	EvHandler      EventHandler
+	Bus            *bus.Bus
 	SelectStrategy string
 }
 
@@ -30,7 +28,7 @@ type State struct {
 	mu sync.RWMutex
 
 	beneficiaryID database.AccountID
-	evHandler     EventHandler
+	bus           *bus.Bus
 
 	genesis genesis.Genesis
 	mempool *mempool.Mempool
@@ -38,21 +36,19 @@ type State struct {
 }
 
 // New constructs a new blockchain for data management.
-func New(cfg Config) (*State, error) {
-
-	// Build a safe event handler function for use.
-	ev := func(v string, args ...any) {
-		if cfg.EvHandler != nil {
-			cfg.EvHandler(v, args...)
-		}
-	}
+func New(ctx context.Context, cfg Config) (*State, error) {
 
 	// Access the storage for the blockchain.
-	db, err := database.New(cfg.Genesis, ev)
+	db, err := database.New(cfg.Genesis)
 	if err != nil {
 		return nil, err
 	}
 
+	// The accounts from genesis are the first accounts created.
+	for accountID, account := range db.Copy() {
+		cfg.Bus.Publish(ctx, bus.AccountCreated{Account: accountID, Balance: account.Balance})
+	}
+
 	mempool, err := mempool.NewWithStrategy(cfg.SelectStrategy)
 	if err != nil {
 		return nil, err
@@ -60,7 +56,7 @@ func New(cfg Config) (*State, error) {
 
 	state := State{
 		beneficiaryID: cfg.BeneficiaryID,
-		evHandler:     ev,
+		bus:           cfg.Bus,
 		mempool:       mempool,
 
 		genesis: cfg.Genesis,
@@ -70,14 +66,19 @@ func New(cfg Config) (*State, error) {
 	return &state, nil
 }
 
-// Shutdown cleanly brings the node down.
-func (s *State) Shutdown() error {
-	s.evHandler("state: shutdown: started")
-	defer s.evHandler("state: shutdown: completed")
+// Shutdown cleanly brings the node down. Once nothing can reach the state
+// anymore, the caller calls ShutdownCompleted.
+func (s *State) Shutdown(ctx context.Context) error {
+	s.bus.Publish(ctx, bus.ShutdownStarted{})
 
 	return nil
 }
 
+// ShutdownCompleted reports the node is done shutting down.
+func (s *State) ShutdownCompleted(ctx context.Context) {
+	s.bus.Publish(ctx, bus.ShutdownCompleted{})
+}
+
 // =============================================================================
 
 // Genesis returns a copy of the genesis information.
@@ -96,8 +97,14 @@ func (s *State) Mempool() []database.BlockTx {
 }
 
 // UpsertMempool adds a new transaction to the mempool.
-func (s *State) UpsertMempool(tx database.BlockTx) error {
-	return s.mempool.Upsert(tx)
+func (s *State) UpsertMempool(ctx context.Context, tx database.BlockTx) error {
+	if err := s.mempool.Upsert(tx); err != nil {
+		return err
+	}
+
+	s.bus.Publish(ctx, bus.TxAdmitted{Hash: tx.TxHash(), Tx: tx})
+
+	return nil
 }
 
 // =============================================================================
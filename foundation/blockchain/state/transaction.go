@@ -1,9 +1,46 @@
 package state
 
-import "github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+import (
+	"context"
+	"fmt"
 
-// UpsertWalletTransaction accepts a transaction from a wallet for inclusion.
-func (s *State) UpsertWalletTransaction(signedTx database.SignedTx) error {
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/bus"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/tracing"
+)
+
+// Set of reasons a transaction can be rejected at admission.
+const (
+	RejectInvalid = "invalid" // The signature, chain id or accounts failed validation.
+	RejectMempool = "mempool" // The mempool refused it, like a replacement without enough tip.
+	RejectName    = "name"    // The name operation in the data can't be applied to the registry.
+)
+
+// RejectedError is returned when a transaction is not admitted to the
+// mempool. The reason allows rejections to be grouped.
+type RejectedError struct {
+	Reason string
+	Err    error
+}
+
+// Error implements the error interface.
+func (re *RejectedError) Error() string {
+	return re.Err.Error()
+}
+
+// Unwrap returns the error that caused the rejection.
+func (re *RejectedError) Unwrap() error {
+	return re.Err
+}
+
+// UpsertWalletTransaction accepts a transaction from a wallet for inclusion
+// and returns the hash that identifies the transaction.
+func (s *State) UpsertWalletTransaction(ctx context.Context, signedTx database.SignedTx) (string, error) {
+	_, span := tracing.Start(ctx, "mempool.admit")
+	defer span.End()
+
+	span.SetAttribute("tx.from", string(signedTx.FromID))
+	span.SetAttribute("tx.nonce", signedTx.Nonce)
 
 	// CORE NOTE: It's up to the wallet to make sure the account has a proper
 	// balance and this transaction has a proper nonce. Fees will be taken if
@@ -13,14 +50,68 @@ func (s *State) UpsertWalletTransaction(signedTx database.SignedTx) error {
 	// Check the signed transaction has a proper signature, the from matches the
 	// signature, and the from and to fields are properly formatted.
 	if err := signedTx.Validate(s.genesis.ChainID); err != nil {
-		return err
+		return "", s.reject(ctx, span, signedTx, RejectInvalid, err)
 	}
 
 	const oneUnitOfGas = 1
 	tx := database.NewBlockTx(signedTx, s.genesis.GasPrice, oneUnitOfGas)
+
+	// The name check and the upsert happen under the lock so two operations
+	// on the same name can't both be admitted.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// A transaction carrying a name operation is checked against the
+	// registry and the name operations already in the mempool so a name
+	// that is taken, not owned by the sender, or already being changed
+	// doesn't sit in the mempool.
+	if nameOp, ok, err := database.ParseNameOp(signedTx.Data); ok {
+		if err != nil {
+			return "", s.reject(ctx, span, signedTx, RejectName, err)
+		}
+		if err := s.db.CheckNameOp(signedTx.Tx, nameOp); err != nil {
+			return "", s.reject(ctx, span, signedTx, RejectName, err)
+		}
+		if err := s.checkPendingName(tx, nameOp); err != nil {
+			return "", s.reject(ctx, span, signedTx, RejectName, err)
+		}
+		span.SetAttribute("tx.name", nameOp.Name)
+	}
+
 	if err := s.mempool.Upsert(tx); err != nil {
-		return err
+		return "", s.reject(ctx, span, signedTx, RejectMempool, err)
+	}
+
+	hash := signedTx.TxHash()
+	span.SetAttribute("tx.hash", hash)
+
+	s.bus.Publish(ctx, bus.TxAdmitted{Hash: hash, Tx: tx})
+
+	return hash, nil
+}
+
+// checkPendingName verifies no other transaction in the mempool carries an
+// operation on the same name. A transaction replacing one from the same
+// account with the same nonce is not a conflict.
+func (s *State) checkPendingName(tx database.BlockTx, nameOp database.NameOp) error {
+	for _, pending := range s.mempool.PickBest() {
+		if pending.FromID == tx.FromID && pending.Nonce == tx.Nonce {
+			continue
+		}
+
+		if pendingOp, ok, _ := database.ParseNameOp(pending.Data); ok && pendingOp.Name == nameOp.Name {
+			return fmt.Errorf("name %q already has a pending %s operation from %s", nameOp.Name, pendingOp.Op, pending.FromID)
+		}
 	}
 
 	return nil
 }
+
+// reject records why the transaction wasn't admitted and returns the error
+// for the caller.
+func (s *State) reject(ctx context.Context, span *tracing.Span, signedTx database.SignedTx, reason string, err error) error {
+	span.SetError(err)
+	s.bus.Publish(ctx, bus.TxRejected{Tx: signedTx, Reason: reason, Err: err})
+
+	return &RejectedError{Reason: reason, Err: err}
+}
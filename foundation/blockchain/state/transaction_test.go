@@ -0,0 +1,100 @@
+package state_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/bus"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/genesis"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/state"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const pavel database.AccountID = "0xbEE6ACE826eC3DE1B6349888B9151B92522F7F76"
+
+func TestUpsertNameOp(t *testing.T) {
+	billKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Should be able to generate bill's key: %s", err)
+	}
+	kennedyKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Should be able to generate kennedy's key: %s", err)
+	}
+	bill := database.PublicKeyToAccountID(billKey.PublicKey)
+	kennedy := database.PublicKeyToAccountID(kennedyKey.PublicKey)
+
+	evts := bus.New(func(ctx context.Context) string { return "" })
+	defer evts.Shutdown()
+
+	st, err := state.New(context.Background(), state.Config{
+		Genesis: genesis.Genesis{
+			ChainID:  1,
+			NameFee:  10,
+			Balances: map[string]uint64{string(bill): 1000, string(kennedy): 1000},
+		},
+		Bus:            evts,
+		SelectStrategy: "tip",
+	})
+	if err != nil {
+		t.Fatalf("Should be able to construct the state: %s", err)
+	}
+
+	// upsert submits a transaction carrying the name operation.
+	upsert := func(t *testing.T, key *ecdsa.PrivateKey, nonce uint64, tip uint64, to database.AccountID, op string, name string) error {
+		data, err := database.NewNameOp(op, name)
+		if err != nil {
+			t.Fatalf("Should be able to construct the name operation: %s", err)
+		}
+
+		tx, err := database.NewTx(1, nonce, database.PublicKeyToAccountID(key.PublicKey), to, 0, tip, data)
+		if err != nil {
+			t.Fatalf("Should be able to construct the transaction: %s", err)
+		}
+
+		signed, err := tx.Sign(key)
+		if err != nil {
+			t.Fatalf("Should be able to sign the transaction: %s", err)
+		}
+
+		_, err = st.UpsertWalletTransaction(context.Background(), signed)
+		return err
+	}
+
+	// rejectedFor reports if the error is a rejection for the reason.
+	rejectedFor := func(err error, reason string) bool {
+		var re *state.RejectedError
+		return errors.As(err, &re) && re.Reason == reason
+	}
+
+	if err := upsert(t, billKey, 1, 0, pavel, database.NameRegister, "kennedy-corp"); err != nil {
+		t.Fatalf("Should admit the registration of an unowned name: %s", err)
+	}
+
+	if err := upsert(t, kennedyKey, 1, 0, pavel, database.NameRegister, "kennedy-corp"); !rejectedFor(err, state.RejectName) {
+		t.Errorf("Should reject a second registration of a pending name, got %v", err)
+	}
+
+	if err := upsert(t, billKey, 2, 0, pavel, database.NameRegister, "kennedy-corp"); !rejectedFor(err, state.RejectName) {
+		t.Errorf("Should reject the same account registering a pending name again, got %v", err)
+	}
+
+	if err := upsert(t, billKey, 1, 10, kennedy, database.NameRegister, "kennedy-corp"); err != nil {
+		t.Errorf("Should admit a replacement of the pending registration: %s", err)
+	}
+
+	if err := upsert(t, kennedyKey, 1, 0, pavel, database.NameRegister, "kennedy-inc"); err != nil {
+		t.Errorf("Should admit the registration of another name: %s", err)
+	}
+
+	if err := upsert(t, kennedyKey, 2, 0, kennedy, database.NameRegister, "kennedy-llc"); !rejectedFor(err, state.RejectInvalid) {
+		t.Errorf("Should reject a name operation sent to yourself, got %v", err)
+	}
+
+	if got := st.MempoolLength(); got != 2 {
+		t.Errorf("Should have 2 transactions in the mempool, got %d", got)
+	}
+}
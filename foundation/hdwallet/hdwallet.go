@@ -0,0 +1,129 @@
+// Package hdwallet provides support for hierarchical deterministic wallets.
+// A BIP-39 mnemonic is turned into a seed and accounts are derived from that
+// seed using BIP-32 along the BIP-44 path used by Ethereum, m/44'/60'/0'/0/N.
+// This allows many accounts to be backed up with a single set of words.
+package hdwallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// hardened is the offset for the index of a hardened child key.
+const hardened uint32 = 0x80000000
+
+// accountPath is the BIP-44 path for Ethereum accounts without the final
+// address index: m/44'/60'/0'/0.
+var accountPath = []uint32{44 + hardened, 60 + hardened, 0 + hardened, 0}
+
+// =============================================================================
+
+// NewMnemonic generates a new 24 word mnemonic.
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return "", err
+	}
+
+	return bip39.NewMnemonic(entropy)
+}
+
+// ValidateMnemonic checks the words and checksum of the mnemonic.
+func ValidateMnemonic(mnemonic string) error {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return errors.New("invalid mnemonic")
+	}
+
+	return nil
+}
+
+// Seed validates the mnemonic and converts it into the seed used to derive
+// accounts. The passphrase is the optional BIP-39 passphrase.
+func Seed(mnemonic string, passphrase string) ([]byte, error) {
+	return bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+}
+
+// Derive returns the private key for the account at the specified index
+// using the path m/44'/60'/0'/0/index.
+func Derive(seed []byte, index uint32) (*ecdsa.PrivateKey, error) {
+	if index >= hardened {
+		return nil, errors.New("account index out of range")
+	}
+
+	key, chainCode, err := masterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range append(accountPath, index) {
+		key, chainCode, err = childKey(key, chainCode, i)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return crypto.ToECDSA(key)
+}
+
+// =============================================================================
+
+// masterKey generates the BIP-32 master key and chain code from the seed.
+func masterKey(seed []byte) (key []byte, chainCode []byte, err error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	k := new(big.Int).SetBytes(sum[:32])
+	if k.Sign() == 0 || k.Cmp(crypto.S256().Params().N) >= 0 {
+		return nil, nil, errors.New("invalid master key, use a different seed")
+	}
+
+	return sum[:32], sum[32:], nil
+}
+
+// childKey derives the BIP-32 private child key at the specified index from
+// the parent key and chain code.
+func childKey(key []byte, chainCode []byte, index uint32) ([]byte, []byte, error) {
+	var data []byte
+	switch {
+	case index >= hardened:
+		data = append([]byte{0}, key...)
+
+	default:
+		privateKey, err := crypto.ToECDSA(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		data = crypto.CompressPubkey(&privateKey.PublicKey)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	// The child key is the parent key plus the left half of the hash.
+	n := crypto.S256().Params().N
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(n) >= 0 {
+		return nil, nil, errors.New("invalid child key, use a different index")
+	}
+
+	k := il.Add(il, new(big.Int).SetBytes(key))
+	k.Mod(k, n)
+	if k.Sign() == 0 {
+		return nil, nil, errors.New("invalid child key, use a different index")
+	}
+
+	child := make([]byte, 32)
+	k.FillBytes(child)
+
+	return child, sum[32:], nil
+}
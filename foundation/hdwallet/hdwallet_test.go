@@ -0,0 +1,90 @@
+package hdwallet
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// mnemonic is the all zero entropy mnemonic used by the BIP-39 test vectors.
+const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestSeed(t *testing.T) {
+	const exp = "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"
+
+	seed, err := Seed(mnemonic, "TREZOR")
+	if err != nil {
+		t.Fatalf("Should be able to convert the mnemonic into a seed: %s", err)
+	}
+
+	if got := hex.EncodeToString(seed); got != exp {
+		t.Errorf("Should get the BIP-39 seed, got %s", got)
+	}
+
+	if _, err := Seed("abandon abandon abandon", ""); err == nil {
+		t.Errorf("Should not accept an invalid mnemonic")
+	}
+}
+
+// The private keys from BIP-32 test vector 1.
+func TestChildKey(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+	tt := []struct {
+		path  string
+		index uint32
+		key   string
+	}{
+		{"m/0H", 0 + hardened, "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0H/1", 1, "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{"m/0H/1/2H", 2 + hardened, "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+		{"m/0H/1/2H/2", 2, "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4"},
+		{"m/0H/1/2H/2/1000000000", 1000000000, "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	}
+
+	key, chainCode, err := masterKey(seed)
+	if err != nil {
+		t.Fatalf("Should be able to generate the master key: %s", err)
+	}
+
+	if got := hex.EncodeToString(key); got != "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35" {
+		t.Fatalf("Should get the master key, got %s", got)
+	}
+
+	// Each child is derived from the one before it.
+	for _, tst := range tt {
+		key, chainCode, err = childKey(key, chainCode, tst.index)
+		if err != nil {
+			t.Fatalf("Should be able to derive %s: %s", tst.path, err)
+		}
+
+		if got := hex.EncodeToString(key); got != tst.key {
+			t.Fatalf("Should get the key for %s, got %s", tst.path, got)
+		}
+	}
+}
+
+func TestDerive(t *testing.T) {
+	seed, err := Seed(mnemonic, "")
+	if err != nil {
+		t.Fatalf("Should be able to convert the mnemonic into a seed: %s", err)
+	}
+
+	privateKey, err := Derive(seed, 0)
+	if err != nil {
+		t.Fatalf("Should be able to derive m/44'/60'/0'/0/0: %s", err)
+	}
+
+	if got := hex.EncodeToString(crypto.FromECDSA(privateKey)); got != "1ab42cc412b618bdea3a599e3c9bae199ebf030895b039e9db1e30dafb12b727" {
+		t.Errorf("Should get the private key for m/44'/60'/0'/0/0, got %s", got)
+	}
+
+	if got := crypto.PubkeyToAddress(privateKey.PublicKey).Hex(); got != "0x9858EfFD232B4033E47d90003D41EC34EcaEda94" {
+		t.Errorf("Should get the account for m/44'/60'/0'/0/0, got %s", got)
+	}
+
+	if _, err := Derive(seed, hardened); err == nil {
+		t.Errorf("Should not derive a hardened account index")
+	}
+}
@@ -0,0 +1,364 @@
+// Package keystore provides support for storing private keys encrypted with
+// a passphrase. The file format is compatible with the Ethereum v3 JSON
+// keystore so keys can be moved between this blockchain and Ethereum tooling.
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Set of file extensions used for private key files.
+const (
+	ExtKeystore = ".keystore"
+	ExtECDSA    = ".ecdsa"
+)
+
+// These are the standard scrypt parameters used by Ethereum. They require
+// 256MB of memory and take about a second of CPU time to derive a key.
+const (
+	scryptN     = 1 << 18
+	scryptR     = 8
+	scryptP     = 1
+	scryptDKLen = 32
+)
+
+// version is the version of the Ethereum keystore format being supported.
+const version = 3
+
+// ErrDecrypt is returned when the passphrase doesn't decrypt the key.
+var ErrDecrypt = errors.New("could not decrypt key with given passphrase")
+
+// =============================================================================
+
+// encryptedKey represents the JSON document stored on disk.
+type encryptedKey struct {
+	Address string     `json:"address,omitempty"`
+	Crypto  cryptoJSON `json:"crypto"`
+	ID      string     `json:"id"`
+	Version int        `json:"version"`
+}
+
+type cryptoJSON struct {
+	Cipher       string          `json:"cipher"`
+	CipherText   string          `json:"ciphertext"`
+	CipherParams cipherParams    `json:"cipherparams"`
+	KDF          string          `json:"kdf"`
+	KDFParams    json.RawMessage `json:"kdfparams"`
+	MAC          string          `json:"mac"`
+}
+
+type cipherParams struct {
+	IV string `json:"iv"`
+}
+
+type scryptParams struct {
+	DKLen int    `json:"dklen"`
+	N     int    `json:"n"`
+	P     int    `json:"p"`
+	R     int    `json:"r"`
+	Salt  string `json:"salt"`
+}
+
+type pbkdf2Params struct {
+	C     int    `json:"c"`
+	DKLen int    `json:"dklen"`
+	PRF   string `json:"prf"`
+	Salt  string `json:"salt"`
+}
+
+// =============================================================================
+
+// Encrypt encrypts the private key with the passphrase and returns the JSON
+// keystore document.
+func Encrypt(privateKey *ecdsa.PrivateKey, passphrase string) ([]byte, error) {
+	cj, err := encryptData(crypto.FromECDSA(privateKey), passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	ek := encryptedKey{
+		Address: hex.EncodeToString(crypto.PubkeyToAddress(privateKey.PublicKey).Bytes()),
+		Crypto:  cj,
+		ID:      uuid.New().String(),
+		Version: version,
+	}
+
+	return json.MarshalIndent(ek, "", "    ")
+}
+
+// Decrypt decrypts the JSON keystore document with the passphrase and returns
+// the private key.
+func Decrypt(content []byte, passphrase string) (*ecdsa.PrivateKey, error) {
+	var ek encryptedKey
+	if err := json.Unmarshal(content, &ek); err != nil {
+		return nil, fmt.Errorf("decoding keystore: %w", err)
+	}
+
+	keyBytes, err := decryptData(ek, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := crypto.ToECDSA(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the key matches the address recorded in the document.
+	if ek.Address != "" && crypto.PubkeyToAddress(privateKey.PublicKey) != common.HexToAddress(ek.Address) {
+		return nil, errors.New("decrypted key doesn't match keystore address")
+	}
+
+	return privateKey, nil
+}
+
+// EncryptData encrypts any secret with the passphrase using the same scheme
+// as the private keys and returns the JSON document. The document has no
+// address since the secret isn't a key.
+func EncryptData(data []byte, passphrase string) ([]byte, error) {
+	cj, err := encryptData(data, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	ek := encryptedKey{
+		Crypto:  cj,
+		ID:      uuid.New().String(),
+		Version: version,
+	}
+
+	return json.MarshalIndent(ek, "", "    ")
+}
+
+// DecryptData decrypts the JSON document produced by EncryptData with the
+// passphrase and returns the secret.
+func DecryptData(content []byte, passphrase string) ([]byte, error) {
+	var ek encryptedKey
+	if err := json.Unmarshal(content, &ek); err != nil {
+		return nil, fmt.Errorf("decoding keystore: %w", err)
+	}
+
+	return decryptData(ek, passphrase)
+}
+
+// =============================================================================
+
+// Store encrypts the private key and writes the keystore file to disk.
+func Store(path string, privateKey *ecdsa.PrivateKey, passphrase string) error {
+	content, err := Encrypt(privateKey, passphrase)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0600)
+}
+
+// Load reads the keystore file from disk and decrypts the private key.
+func Load(path string, passphrase string) (*ecdsa.PrivateKey, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Decrypt(content, passphrase)
+}
+
+// Address reads the account address from the keystore file without the
+// need to decrypt the private key.
+func Address(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	var ek encryptedKey
+	if err := json.Unmarshal(content, &ek); err != nil {
+		return "", fmt.Errorf("decoding keystore: %w", err)
+	}
+
+	if !common.IsHexAddress(ek.Address) {
+		return "", fmt.Errorf("keystore %s has an invalid address", path)
+	}
+
+	return common.HexToAddress(ek.Address).String(), nil
+}
+
+// LoadKey loads the private key for the named account from the folder. An
+// encrypted keystore file takes precedence over a plain .ecdsa file. The
+// passphrase function is only called when the keystore file is used.
+func LoadKey(folder string, name string, passphrase func() (string, error)) (*ecdsa.PrivateKey, error) {
+	path := filepath.Join(folder, name+ExtKeystore)
+	if _, err := os.Stat(path); err != nil {
+		return crypto.LoadECDSA(filepath.Join(folder, name+ExtECDSA))
+	}
+
+	pass, err := passphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	return Load(path, pass)
+}
+
+// =============================================================================
+
+// encryptData encrypts the data with a key derived from the passphrase using
+// scrypt and returns the crypto section of the document.
+func encryptData(data []byte, passphrase string) (cryptoJSON, error) {
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return cryptoJSON{}, fmt.Errorf("reading salt: %w", err)
+	}
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return cryptoJSON{}, err
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return cryptoJSON{}, fmt.Errorf("reading iv: %w", err)
+	}
+
+	cipherText, err := aesCTRXOR(derivedKey[:16], data, iv)
+	if err != nil {
+		return cryptoJSON{}, err
+	}
+
+	kdfParams, err := json.Marshal(scryptParams{
+		DKLen: scryptDKLen,
+		N:     scryptN,
+		P:     scryptP,
+		R:     scryptR,
+		Salt:  hex.EncodeToString(salt),
+	})
+	if err != nil {
+		return cryptoJSON{}, err
+	}
+
+	cj := cryptoJSON{
+		Cipher:       "aes-128-ctr",
+		CipherText:   hex.EncodeToString(cipherText),
+		CipherParams: cipherParams{IV: hex.EncodeToString(iv)},
+		KDF:          "scrypt",
+		KDFParams:    kdfParams,
+		MAC:          hex.EncodeToString(crypto.Keccak256(derivedKey[16:32], cipherText)),
+	}
+
+	return cj, nil
+}
+
+// decryptData checks the document and passphrase and returns the decrypted
+// data.
+func decryptData(ek encryptedKey, passphrase string) ([]byte, error) {
+	if ek.Version != version {
+		return nil, fmt.Errorf("keystore version %d not supported", ek.Version)
+	}
+
+	if ek.Crypto.Cipher != "aes-128-ctr" {
+		return nil, fmt.Errorf("cipher %q not supported", ek.Crypto.Cipher)
+	}
+
+	cipherText, err := hex.DecodeString(ek.Crypto.CipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	iv, err := hex.DecodeString(ek.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(iv) != aes.BlockSize {
+		return nil, fmt.Errorf("iv must be %d bytes, got %d", aes.BlockSize, len(iv))
+	}
+
+	mac, err := hex.DecodeString(ek.Crypto.MAC)
+	if err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := deriveKey(ek.Crypto, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(derivedKey) < 32 {
+		return nil, errors.New("derived key is too short")
+	}
+
+	if !bytes.Equal(crypto.Keccak256(derivedKey[16:32], cipherText), mac) {
+		return nil, ErrDecrypt
+	}
+
+	return aesCTRXOR(derivedKey[:16], cipherText, iv)
+}
+
+// deriveKey uses the key derivation function described in the document to
+// produce the key for the passphrase.
+func deriveKey(cj cryptoJSON, passphrase string) ([]byte, error) {
+	switch cj.KDF {
+	case "scrypt":
+		var p scryptParams
+		if err := json.Unmarshal(cj.KDFParams, &p); err != nil {
+			return nil, err
+		}
+
+		salt, err := hex.DecodeString(p.Salt)
+		if err != nil {
+			return nil, err
+		}
+
+		return scrypt.Key([]byte(passphrase), salt, p.N, p.R, p.P, p.DKLen)
+
+	case "pbkdf2":
+		var p pbkdf2Params
+		if err := json.Unmarshal(cj.KDFParams, &p); err != nil {
+			return nil, err
+		}
+
+		if p.PRF != "hmac-sha256" {
+			return nil, fmt.Errorf("pbkdf2 prf %q not supported", p.PRF)
+		}
+
+		salt, err := hex.DecodeString(p.Salt)
+		if err != nil {
+			return nil, err
+		}
+
+		return pbkdf2.Key([]byte(passphrase), salt, p.C, p.DKLen, sha256.New), nil
+	}
+
+	return nil, fmt.Errorf("kdf %q not supported", cj.KDF)
+}
+
+// aesCTRXOR encrypts or decrypts the data using AES in counter mode.
+func aesCTRXOR(key []byte, data []byte, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, len(data))
+	cipher.NewCTR(block, iv).XORKeyStream(out, data)
+
+	return out, nil
+}
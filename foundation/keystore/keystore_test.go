@@ -0,0 +1,160 @@
+package keystore_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestRoundTrip(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Should be able to generate a private key: %s", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "kennedy"+keystore.ExtKeystore)
+	if err := keystore.Store(path, privateKey, "secret"); err != nil {
+		t.Fatalf("Should be able to store the private key: %s", err)
+	}
+
+	address, err := keystore.Address(path)
+	if err != nil {
+		t.Fatalf("Should be able to read the address: %s", err)
+	}
+	if exp := crypto.PubkeyToAddress(privateKey.PublicKey).Hex(); address != exp {
+		t.Errorf("Should get back the account address, got %s, exp %s", address, exp)
+	}
+
+	got, err := keystore.Load(path, "secret")
+	if err != nil {
+		t.Fatalf("Should be able to load the private key: %s", err)
+	}
+	if !got.Equal(privateKey) {
+		t.Errorf("Should get back the same private key")
+	}
+
+	if _, err := keystore.Load(path, "wrong"); !errors.Is(err, keystore.ErrDecrypt) {
+		t.Errorf("Should get ErrDecrypt for the wrong passphrase, got %v", err)
+	}
+}
+
+// The test vectors from the Web3 Secret Storage Definition. Both documents
+// hold the same private key encrypted with the passphrase "testpassword".
+func TestSpecVectors(t *testing.T) {
+	const privateKey = "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d"
+
+	tt := []struct {
+		name     string
+		document string
+	}{
+		{
+			name: "pbkdf2",
+			document: `{
+				"crypto": {
+					"cipher": "aes-128-ctr",
+					"cipherparams": {"iv": "6087dab2f9fdbbfaddc31a909735c1e6"},
+					"ciphertext": "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",
+					"kdf": "pbkdf2",
+					"kdfparams": {
+						"c": 262144,
+						"dklen": 32,
+						"prf": "hmac-sha256",
+						"salt": "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"
+					},
+					"mac": "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"
+				},
+				"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+				"version": 3
+			}`,
+		},
+		{
+			name: "scrypt",
+			document: `{
+				"crypto": {
+					"cipher": "aes-128-ctr",
+					"cipherparams": {"iv": "83dbcc02d8ccb40e466191a123791e0e"},
+					"ciphertext": "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+					"kdf": "scrypt",
+					"kdfparams": {
+						"dklen": 32,
+						"n": 262144,
+						"p": 8,
+						"r": 1,
+						"salt": "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"
+					},
+					"mac": "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"
+				},
+				"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+				"version": 3
+			}`,
+		},
+	}
+
+	for _, tst := range tt {
+		t.Run(tst.name, func(t *testing.T) {
+			got, err := keystore.Decrypt([]byte(tst.document), "testpassword")
+			if err != nil {
+				t.Fatalf("Should be able to decrypt the document: %s", err)
+			}
+
+			if key := hex.EncodeToString(crypto.FromECDSA(got)); key != privateKey {
+				t.Errorf("Should get the spec private key, got %s", key)
+			}
+
+			if _, err := keystore.Decrypt([]byte(tst.document), "wrong"); !errors.Is(err, keystore.ErrDecrypt) {
+				t.Errorf("Should get ErrDecrypt for the wrong passphrase, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDataRoundTrip(t *testing.T) {
+	const secret = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	content, err := keystore.EncryptData([]byte(secret), "secret")
+	if err != nil {
+		t.Fatalf("Should be able to encrypt the data: %s", err)
+	}
+
+	got, err := keystore.DecryptData(content, "secret")
+	if err != nil {
+		t.Fatalf("Should be able to decrypt the data: %s", err)
+	}
+	if string(got) != secret {
+		t.Errorf("Should get back the same data, got %q", got)
+	}
+
+	if _, err := keystore.DecryptData(content, "wrong"); !errors.Is(err, keystore.ErrDecrypt) {
+		t.Errorf("Should get ErrDecrypt for the wrong passphrase, got %v", err)
+	}
+}
+
+func TestBadIV(t *testing.T) {
+	content, err := keystore.EncryptData([]byte("secret data"), "secret")
+	if err != nil {
+		t.Fatalf("Should be able to encrypt the data: %s", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(content, &doc); err != nil {
+		t.Fatalf("Should be able to decode the document: %s", err)
+	}
+
+	for _, iv := range []string{"", "00", "000102030405060708090a0b0c0d0e0f10"} {
+		doc["crypto"].(map[string]any)["cipherparams"] = map[string]any{"iv": iv}
+
+		content, err := json.Marshal(doc)
+		if err != nil {
+			t.Fatalf("Should be able to encode the document: %s", err)
+		}
+
+		if _, err := keystore.DecryptData(content, "secret"); err == nil {
+			t.Errorf("Should reject an iv of %d bytes", len(iv)/2)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ReadPassphrase reads the passphrase from the specified file. Only the
+// first line of the file is used.
+func ReadPassphrase(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading passphrase file: %w", err)
+	}
+
+	passphrase, _, _ := strings.Cut(string(content), "\n")
+	return strings.TrimSuffix(passphrase, "\r"), nil
+}
+
+// PromptPassphrase asks for the passphrase on the terminal without echoing
+// the input. If confirm is true, the passphrase must be entered twice.
+func PromptPassphrase(prompt string, confirm bool) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", errors.New("passphrase required but no terminal is available to prompt")
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+
+	if confirm {
+		fmt.Fprint(os.Stderr, "Repeat passphrase: ")
+		again, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+
+		if string(passphrase) != string(again) {
+			return "", errors.New("passphrases do not match")
+		}
+	}
+
+	return string(passphrase), nil
+}
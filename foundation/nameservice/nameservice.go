@@ -8,63 +8,177 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/keystore"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
-// NameService maintains a map of accounts for name lookup.
+// Registry represents the on-chain name registry the name service falls
+// back to for accounts and names that aren't in the folder.
+type Registry interface {
+	QueryName(name string) (database.NameRecord, error)
+	QueryOwnedNames(accountID database.AccountID) []string
+}
+
+// NameService maintains a map of accounts for name lookup. The names can be
+// reloaded from the folder while the service is in use.
 type NameService struct {
+	root string
+
+	mu       sync.RWMutex
 	accounts map[database.AccountID]string
+	names    map[string]database.AccountID
+	registry Registry
 }
 
 // New constructs an Ardan Name Service with accounts from the zblock/accounts folder.
+// Both plain .ecdsa key files and encrypted .keystore files are discovered.
 func New(root string) (*NameService, error) {
 	ns := NameService{
-		accounts: make(map[database.AccountID]string),
+		root: root,
 	}
 
+	if _, err := ns.Reload(); err != nil {
+		return nil, err
+	}
+
+	return &ns, nil
+}
+
+// Reload walks the folder again so keys that were added or removed since the
+// last load are picked up. It reports if the names changed. When the folder
+// can't be read, the current names are kept.
+func (ns *NameService) Reload() (bool, error) {
+	accounts := make(map[database.AccountID]string)
+	names := make(map[string]database.AccountID)
+
 	fn := func(fileName string, info fs.FileInfo, err error) error {
 		if err != nil {
 			return fmt.Errorf("walkdir failure: %w", err)
 		}
 
-		if path.Ext(fileName) != ".ecdsa" {
-			return nil
-		}
+		var accountID database.AccountID
+		switch path.Ext(fileName) {
+		case keystore.ExtECDSA:
+			privateKey, err := crypto.LoadECDSA(fileName)
+			if err != nil {
+				return err
+			}
+			accountID = database.PublicKeyToAccountID(privateKey.PublicKey)
 
-		privateKey, err := crypto.LoadECDSA(fileName)
-		if err != nil {
-			return err
+		case keystore.ExtKeystore:
+
+			// The address is stored in the clear so there is
+			// no need for the passphrase.
+			address, err := keystore.Address(fileName)
+			if err != nil {
+				return err
+			}
+			accountID = database.AccountID(address)
+
+		default:
+			return nil
 		}
 
-		accountID := database.PublicKeyToAccountID(privateKey.PublicKey)
-		ns.accounts[accountID] = strings.TrimSuffix(path.Base(fileName), ".ecdsa")
+		// An account can have more than one key file, in which case every
+		// name resolves to it and the last one found is used for lookups.
+		name := strings.TrimSuffix(path.Base(fileName), path.Ext(fileName))
+		accounts[accountID] = name
+		names[name] = accountID
 
 		return nil
 	}
 
-	if err := filepath.Walk(root, fn); err != nil {
-		return nil, fmt.Errorf("walking directory: %w", err)
+	if err := filepath.Walk(ns.root, fn); err != nil {
+		return false, fmt.Errorf("walking directory: %w", err)
 	}
 
-	return &ns, nil
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	changed := len(names) != len(ns.names)
+	for name, accountID := range names {
+		if current, exists := ns.names[name]; !exists || current != accountID {
+			changed = true
+			break
+		}
+	}
+
+	ns.accounts = accounts
+	ns.names = names
+
+	return changed, nil
 }
 
-// Lookup returns the name for the specified account.
+// SetRegistry sets the on-chain registry used for the accounts and names
+// that aren't in the folder.
+func (ns *NameService) SetRegistry(registry Registry) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	ns.registry = registry
+}
+
+// Lookup returns the name for the specified account. The names in the
+// folder take priority over the ones in the registry.
 func (ns *NameService) Lookup(accountID database.AccountID) string {
-	name, exists := ns.accounts[accountID]
-	if !exists {
-		return string(accountID)
+	ns.mu.RLock()
+	defer ns.mu.RUnlock()
+
+	if name, exists := ns.accounts[accountID]; exists {
+		return name
 	}
-	return name
+
+	if ns.registry != nil {
+		if names := ns.registry.QueryOwnedNames(accountID); len(names) > 0 {
+			return names[0]
+		}
+	}
+
+	return string(accountID)
+}
+
+// Resolve returns the account for the specified name. The names in the
+// folder take priority over the ones in the registry.
+func (ns *NameService) Resolve(name string) (database.AccountID, bool) {
+	ns.mu.RLock()
+	defer ns.mu.RUnlock()
+
+	if accountID, exists := ns.names[name]; exists {
+		return accountID, true
+	}
+
+	if ns.registry != nil {
+		if record, err := ns.registry.QueryName(name); err == nil {
+			return record.Owner, true
+		}
+	}
+
+	return "", false
 }
 
 // Copy returns a copy of the map of names and accounts.
 func (ns *NameService) Copy() map[database.AccountID]string {
+	ns.mu.RLock()
+	defer ns.mu.RUnlock()
+
 	accounts := make(map[database.AccountID]string, len(ns.accounts))
 	for account, name := range ns.accounts {
 		accounts[account] = name
 	}
 	return accounts
 }
+
+// Names returns a copy of the map of accounts keyed by name.
+func (ns *NameService) Names() map[string]database.AccountID {
+	ns.mu.RLock()
+	defer ns.mu.RUnlock()
+
+	names := make(map[string]database.AccountID, len(ns.names))
+	for name, account := range ns.names {
+		names[name] = account
+	}
+	return names
+}
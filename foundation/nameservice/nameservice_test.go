@@ -0,0 +1,125 @@
+package nameservice_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/blockchain/database"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/keystore"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/nameservice"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// saveKey writes a new key file for the name and returns its account.
+func saveKey(folder string, name string) (database.AccountID, error) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		return "", err
+	}
+
+	if err := crypto.SaveECDSA(filepath.Join(folder, name+keystore.ExtECDSA), privateKey); err != nil {
+		return "", err
+	}
+
+	return database.PublicKeyToAccountID(privateKey.PublicKey), nil
+}
+
+// addKey calls saveKey and fails the test on error.
+func addKey(t *testing.T, folder string, name string) database.AccountID {
+	accountID, err := saveKey(folder, name)
+	if err != nil {
+		t.Fatalf("Should be able to save the key for %s: %s", name, err)
+	}
+
+	return accountID
+}
+
+func TestReload(t *testing.T) {
+	folder := t.TempDir()
+	bill := addKey(t, folder, "bill")
+
+	ns, err := nameservice.New(folder)
+	if err != nil {
+		t.Fatalf("Should be able to construct the name service: %s", err)
+	}
+
+	if changed, err := ns.Reload(); err != nil || changed {
+		t.Fatalf("Should reload without changes, got %v %v", changed, err)
+	}
+
+	pavel := addKey(t, folder, "pavel")
+	if changed, err := ns.Reload(); err != nil || !changed {
+		t.Fatalf("Should reload with the new key, got %v %v", changed, err)
+	}
+
+	if name := ns.Lookup(bill); name != "bill" {
+		t.Errorf("Should find bill, got %q", name)
+	}
+	if accountID, exists := ns.Resolve("pavel"); !exists || accountID != pavel {
+		t.Errorf("Should resolve the new name pavel, got %q %v", accountID, exists)
+	}
+}
+
+// The test is meant to run with the race detector.
+func TestReloadConcurrent(t *testing.T) {
+	folder := t.TempDir()
+	bill := addKey(t, folder, "bill")
+
+	ns, err := nameservice.New(folder)
+	if err != nil {
+		t.Fatalf("Should be able to construct the name service: %s", err)
+	}
+
+	const readers = 4
+	const reloads = 20
+
+	var wg sync.WaitGroup
+	wg.Add(readers + 1)
+
+	stop := make(chan struct{})
+	for i := 0; i < readers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				// Bill never leaves the folder, so every lookup finds him
+				// whatever reload is in progress.
+				if name := ns.Lookup(bill); name != "bill" {
+					t.Errorf("Should always find bill, got %q", name)
+					return
+				}
+				ns.Resolve("bill")
+				ns.Names()
+				ns.Copy()
+			}
+		}()
+	}
+
+	go func() {
+		defer wg.Done()
+		defer close(stop)
+		for i := 0; i < reloads; i++ {
+			if _, err := saveKey(folder, fmt.Sprintf("user%d", i)); err != nil {
+				t.Errorf("Should be able to save the key: %s", err)
+				return
+			}
+			if _, err := ns.Reload(); err != nil {
+				t.Errorf("Should be able to reload: %s", err)
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if got := len(ns.Names()); got != reloads+1 {
+		t.Errorf("Should have every name after the reloads, got %d", got)
+	}
+}
@@ -0,0 +1,104 @@
+// Package ratelimit provides a token bucket rate limiter that keeps a bucket
+// for each key, such as a remote IP or an account.
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often buckets that refilled completely are removed so
+// the limiter doesn't grow with every key it has ever seen.
+const sweepInterval = time.Minute
+
+// bucket represents the tokens available for a key.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter maintains a token bucket for each key. Each bucket holds up to
+// burst tokens and refills at rate tokens per second.
+type Limiter struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// New constructs a limiter that allows rate requests per second for each key
+// with bursts of up to burst requests. A rate of zero disables the limiter.
+func New(rate float64, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &Limiter{
+		rate:      rate,
+		burst:     float64(burst),
+		now:       time.Now,
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow takes a token from the bucket for the key. When no token is
+// available it returns false along with how long until one will be.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	if l == nil || l.rate <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, exists := l.buckets[key]
+	if !exists {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+
+	// Add the tokens earned since the bucket was last used.
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+		return false, wait
+	}
+	b.tokens--
+
+	return true, 0
+}
+
+// RetryAfter converts the wait returned by Allow into the whole number of
+// seconds a client should wait before trying again, which is at least one.
+func RetryAfter(wait time.Duration) int {
+	seconds := int(math.Ceil(wait.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return seconds
+}
+
+// sweep removes the buckets that have refilled completely, since they are
+// the same as a new bucket.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
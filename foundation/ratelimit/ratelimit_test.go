@@ -0,0 +1,118 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+// clock is a time source the test moves forward by hand.
+type clock struct {
+	t time.Time
+}
+
+func newClock() *clock {
+	return &clock{t: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *clock) now() time.Time {
+	return c.t
+}
+
+func (c *clock) advance(d time.Duration) {
+	c.t = c.t.Add(d)
+}
+
+// newLimiter constructs a limiter that reads the time from the clock.
+func newLimiter(c *clock, rate float64, burst int) *Limiter {
+	l := New(rate, burst)
+	l.now = c.now
+	l.lastSweep = c.now()
+	return l
+}
+
+func TestBurstAndRefill(t *testing.T) {
+	c := newClock()
+	l := newLimiter(c, 2, 3)
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := l.Allow("bill"); !ok {
+			t.Fatalf("Should allow request %d within the burst", i)
+		}
+	}
+
+	ok, wait := l.Allow("bill")
+	if ok {
+		t.Fatalf("Should not allow a request past the burst")
+	}
+	if wait != 500*time.Millisecond {
+		t.Errorf("Should wait for one token at 2 per second, got %s", wait)
+	}
+
+	// Other keys have their own bucket.
+	if ok, _ := l.Allow("pavel"); !ok {
+		t.Errorf("Should allow a request for a different key")
+	}
+
+	c.advance(250 * time.Millisecond)
+	if ok, wait := l.Allow("bill"); ok || wait != 250*time.Millisecond {
+		t.Errorf("Should wait for the rest of the token, got %v %s", ok, wait)
+	}
+
+	c.advance(250 * time.Millisecond)
+	if ok, _ := l.Allow("bill"); !ok {
+		t.Errorf("Should allow a request once a token refilled")
+	}
+
+	// The bucket never holds more than the burst.
+	c.advance(time.Hour)
+	for i := 0; i < 3; i++ {
+		if ok, _ := l.Allow("bill"); !ok {
+			t.Fatalf("Should allow request %d after refilling", i)
+		}
+	}
+	if ok, _ := l.Allow("bill"); ok {
+		t.Errorf("Should not refill past the burst")
+	}
+}
+
+func TestSweep(t *testing.T) {
+	c := newClock()
+	l := newLimiter(c, 1, 100)
+
+	l.Allow("bill")
+	c.advance(50 * time.Second)
+	for i := 0; i < 20; i++ {
+		l.Allow("pavel")
+	}
+
+	// Bill refilled completely but the sweep hasn't run yet.
+	c.advance(sweepInterval - 50*time.Second - time.Nanosecond)
+	l.Allow("ed")
+	if len(l.buckets) != 3 {
+		t.Fatalf("Should keep the buckets before the sweep interval, got %d", len(l.buckets))
+	}
+
+	// The sweep removes bill, who refilled, and keeps pavel and ed.
+	c.advance(time.Nanosecond)
+	l.Allow("ed")
+	if _, exists := l.buckets["bill"]; exists {
+		t.Errorf("Should remove a bucket that refilled completely")
+	}
+	if _, exists := l.buckets["pavel"]; !exists {
+		t.Errorf("Should keep a bucket that is still refilling")
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	var nilLimiter *Limiter
+	if ok, _ := nilLimiter.Allow("bill"); !ok {
+		t.Errorf("Should allow every request with a nil limiter")
+	}
+
+	l := New(0, 1)
+	for i := 0; i < 10; i++ {
+		if ok, _ := l.Allow("bill"); !ok {
+			t.Fatalf("Should allow every request with a rate of zero")
+		}
+	}
+}
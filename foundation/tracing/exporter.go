@@ -0,0 +1,79 @@
+package tracing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Record represents a finished span as it is exported.
+type Record struct {
+	TraceID    string         `json:"trace_id"`
+	SpanID     string         `json:"span_id"`
+	ParentID   string         `json:"parent_id,omitempty"`
+	Name       string         `json:"name"`
+	Service    string         `json:"service"`
+	Start      time.Time      `json:"start"`
+	End        time.Time      `json:"end"`
+	Duration   string         `json:"duration"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+	Error      string         `json:"error,omitempty"`
+}
+
+// Exporter defines the behavior required to send finished spans somewhere
+// they can be looked at.
+type Exporter interface {
+	Export(rec Record) error
+}
+
+// =============================================================================
+
+// WriterExporter writes each span as a line of JSON.
+type WriterExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterExporter constructs an exporter that writes to the writer.
+func NewWriterExporter(w io.Writer) *WriterExporter {
+	return &WriterExporter{
+		w: w,
+	}
+}
+
+// NewFileExporter constructs an exporter that appends to the file.
+func NewFileExporter(path string) (*WriterExporter, io.Closer, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening trace file: %w", err)
+	}
+
+	return NewWriterExporter(f), f, nil
+}
+
+// Export implements the Exporter interface.
+func (we *WriterExporter) Export(rec Record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	we.mu.Lock()
+	defer we.mu.Unlock()
+
+	_, err = we.w.Write(append(data, '\n'))
+	return err
+}
+
+// =============================================================================
+
+// nopExporter drops every span.
+type nopExporter struct{}
+
+// Export implements the Exporter interface.
+func (nopExporter) Export(rec Record) error {
+	return nil
+}
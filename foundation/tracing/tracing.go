@@ -0,0 +1,299 @@
+// Package tracing provides support for distributed tracing using the W3C
+// Trace Context standard. Incoming traceparent headers are honored so a
+// trace can be followed across nodes, and finished spans are handed to a
+// pluggable exporter.
+// https://www.w3.org/TR/trace-context/
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HeaderTraceParent is the header carrying the trace context.
+const HeaderTraceParent = "traceparent"
+
+// flagSampled is the trace flag marking the trace as recorded.
+const flagSampled = 0x01
+
+// =============================================================================
+
+// TraceID uniquely identifies a trace.
+type TraceID [16]byte
+
+// String returns the hex encoding of the trace id.
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// SpanID uniquely identifies a span within a trace.
+type SpanID [8]byte
+
+// String returns the hex encoding of the span id.
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// SpanContext represents the part of a span that is propagated between
+// services.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Flags   byte
+}
+
+// Sampled reports if the trace is being recorded.
+func (sc SpanContext) Sampled() bool {
+	return sc.Flags&flagSampled != 0
+}
+
+// TraceParent returns the span context in the traceparent header format.
+func (sc SpanContext) TraceParent() string {
+	return fmt.Sprintf("00-%s-%s-%02x", sc.TraceID, sc.SpanID, sc.Flags)
+}
+
+// ParseTraceParent parses the value of a traceparent header.
+func ParseTraceParent(value string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 {
+		return SpanContext{}, errors.New("traceparent must have 4 parts")
+	}
+
+	var version [1]byte
+	if err := decodeHex(parts[0], version[:]); err != nil || version[0] == 0xff {
+		return SpanContext{}, errors.New("invalid traceparent version")
+	}
+
+	// Version 00 has exactly 4 parts, later versions can add more.
+	if version[0] == 0 && len(parts) != 4 {
+		return SpanContext{}, errors.New("traceparent version 00 must have 4 parts")
+	}
+
+	var sc SpanContext
+	if err := decodeHex(parts[1], sc.TraceID[:]); err != nil || sc.TraceID == (TraceID{}) {
+		return SpanContext{}, errors.New("invalid traceparent trace id")
+	}
+
+	if err := decodeHex(parts[2], sc.SpanID[:]); err != nil || sc.SpanID == (SpanID{}) {
+		return SpanContext{}, errors.New("invalid traceparent parent id")
+	}
+
+	var flags [1]byte
+	if err := decodeHex(parts[3], flags[:]); err != nil {
+		return SpanContext{}, errors.New("invalid traceparent flags")
+	}
+	sc.Flags = flags[0]
+
+	return sc, nil
+}
+
+// =============================================================================
+
+// Span represents a unit of work within a trace.
+type Span struct {
+	tracer *Tracer
+	sc     SpanContext
+	parent SpanID
+	name   string
+	start  time.Time
+
+	mu    sync.Mutex
+	attrs map[string]any
+	err   error
+	ended bool
+}
+
+// SpanContext returns the span context to propagate.
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.sc
+}
+
+// SetAttribute records a key/value pair describing the work.
+func (s *Span) SetAttribute(key string, value any) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attrs[key] = value
+}
+
+// SetError records the error that caused the work to fail.
+func (s *Span) SetError(err error) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.err = err
+}
+
+// End completes the span and exports it when the trace is sampled.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+
+	rec := Record{
+		TraceID:    s.sc.TraceID.String(),
+		SpanID:     s.sc.SpanID.String(),
+		Name:       s.name,
+		Service:    s.tracer.service,
+		Start:      s.start,
+		End:        time.Now().UTC(),
+		Attributes: s.attrs,
+	}
+	if s.parent != (SpanID{}) {
+		rec.ParentID = s.parent.String()
+	}
+	if s.err != nil {
+		rec.Error = s.err.Error()
+	}
+	rec.Duration = rec.End.Sub(rec.Start).String()
+	s.mu.Unlock()
+
+	if s.sc.Sampled() {
+		s.tracer.export(rec)
+	}
+}
+
+// =============================================================================
+
+// Tracer creates spans and hands the finished spans to the exporter.
+type Tracer struct {
+	service  string
+	exporter Exporter
+	onError  func(err error)
+}
+
+// NewTracer constructs a tracer for the service. Errors from the exporter
+// are passed to the error function, which can be nil.
+func NewTracer(service string, exporter Exporter, onError func(err error)) *Tracer {
+	if exporter == nil {
+		exporter = nopExporter{}
+	}
+
+	return &Tracer{
+		service:  service,
+		exporter: exporter,
+		onError:  onError,
+	}
+}
+
+// StartRemote starts a span that continues the trace from the remote span
+// context. When the remote span context is invalid, a new trace is started.
+func (t *Tracer) StartRemote(ctx context.Context, remote SpanContext, name string) (context.Context, *Span) {
+	if remote.TraceID == (TraceID{}) {
+		return t.start(ctx, SpanContext{Flags: flagSampled}, name)
+	}
+
+	return t.start(ctx, remote, name)
+}
+
+// start starts a span as a child of the parent span context.
+func (t *Tracer) start(ctx context.Context, parent SpanContext, name string) (context.Context, *Span) {
+	sc := SpanContext{
+		TraceID: parent.TraceID,
+		Flags:   parent.Flags,
+	}
+
+	if sc.TraceID == (TraceID{}) {
+		rand.Read(sc.TraceID[:])
+	}
+	rand.Read(sc.SpanID[:])
+
+	span := Span{
+		tracer: t,
+		sc:     sc,
+		parent: parent.SpanID,
+		name:   name,
+		start:  time.Now().UTC(),
+		attrs:  make(map[string]any),
+	}
+
+	return context.WithValue(ctx, key, &span), &span
+}
+
+// export hands the span to the exporter.
+func (t *Tracer) export(rec Record) {
+	if err := t.exporter.Export(rec); err != nil && t.onError != nil {
+		t.onError(err)
+	}
+}
+
+// =============================================================================
+
+// ctxKey represents the type of value for the context key.
+type ctxKey int
+
+// key is how the current span is stored/retrieved.
+const key ctxKey = 1
+
+// Start starts a span as a child of the span in the context. When there is
+// no span in the context, a nil span is returned which is safe to use and
+// records nothing.
+func Start(ctx context.Context, name string) (context.Context, *Span) {
+	parent := SpanFromContext(ctx)
+	if parent == nil {
+		return ctx, nil
+	}
+
+	return parent.tracer.start(ctx, parent.sc, name)
+}
+
+// SpanFromContext returns the current span, or nil if there isn't one.
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(key).(*Span)
+	return span
+}
+
+// Inject sets the traceparent header from the span in the context so the
+// trace continues in the service receiving the request.
+func Inject(ctx context.Context, header http.Header) {
+	if span := SpanFromContext(ctx); span != nil {
+		header.Set(HeaderTraceParent, span.sc.TraceParent())
+	}
+}
+
+// Extract returns the span context from the traceparent header.
+func Extract(header http.Header) (SpanContext, bool) {
+	sc, err := ParseTraceParent(header.Get(HeaderTraceParent))
+	if err != nil {
+		return SpanContext{}, false
+	}
+	return sc, true
+}
+
+// =============================================================================
+
+// decodeHex decodes the lowercase hex string into the destination, which
+// must be filled exactly.
+func decodeHex(s string, dst []byte) error {
+	if len(s) != 2*len(dst) || strings.ToLower(s) != s {
+		return errors.New("invalid length or case")
+	}
+
+	_, err := hex.Decode(dst, []byte(s))
+	return err
+}
@@ -0,0 +1,125 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/tracing"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+
+	tt := []struct {
+		name    string
+		value   string
+		valid   bool
+		sampled bool
+	}{
+		{name: "valid", value: "00-" + traceID + "-" + spanID + "-01", valid: true, sampled: true},
+		{name: "not sampled", value: "00-" + traceID + "-" + spanID + "-00", valid: true},
+		{name: "surrounding space", value: " 00-" + traceID + "-" + spanID + "-01 ", valid: true, sampled: true},
+		{name: "later version with more parts", value: "01-" + traceID + "-" + spanID + "-01-extra", valid: true, sampled: true},
+		{name: "empty", value: ""},
+		{name: "all zero trace id", value: "00-00000000000000000000000000000000-" + spanID + "-01"},
+		{name: "all zero parent id", value: "00-" + traceID + "-0000000000000000-01"},
+		{name: "uppercase trace id", value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-" + spanID + "-01"},
+		{name: "uppercase version", value: "0A-" + traceID + "-" + spanID + "-01"},
+		{name: "version ff", value: "ff-" + traceID + "-" + spanID + "-01"},
+		{name: "version too long", value: "000-" + traceID + "-" + spanID + "-01"},
+		{name: "version not hex", value: "zz-" + traceID + "-" + spanID + "-01"},
+		{name: "too few parts", value: "00-" + traceID + "-" + spanID},
+		{name: "version 00 with extra part", value: "00-" + traceID + "-" + spanID + "-01-extra"},
+		{name: "short trace id", value: "00-4bf92f3577b34da6-" + spanID + "-01"},
+		{name: "short parent id", value: "00-" + traceID + "-00f067aa-01"},
+		{name: "bad flags", value: "00-" + traceID + "-" + spanID + "-1"},
+	}
+
+	for _, tst := range tt {
+		t.Run(tst.name, func(t *testing.T) {
+			sc, err := tracing.ParseTraceParent(tst.value)
+			if !tst.valid {
+				if err == nil {
+					t.Errorf("Should reject %q", tst.value)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Should parse %q: %s", tst.value, err)
+			}
+			if sc.TraceID.String() != traceID || sc.SpanID.String() != spanID {
+				t.Errorf("Should get the trace and parent ids, got %s %s", sc.TraceID, sc.SpanID)
+			}
+			if sc.Sampled() != tst.sampled {
+				t.Errorf("Should get sampled %v, got %v", tst.sampled, sc.Sampled())
+			}
+		})
+	}
+}
+
+func TestPropagation(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := tracing.NewTracer("node", tracing.NewWriterExporter(&buf), nil)
+
+	remote, err := tracing.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if err != nil {
+		t.Fatalf("Should parse the traceparent: %s", err)
+	}
+
+	ctx, span := tracer.StartRemote(context.Background(), remote, "request")
+	_, child := tracing.Start(ctx, "child")
+
+	header := make(http.Header)
+	tracing.Inject(ctx, header)
+
+	got, ok := tracing.Extract(header)
+	if !ok || got != span.SpanContext() {
+		t.Fatalf("Should extract the injected span context, got %+v", got)
+	}
+
+	child.End()
+	span.End()
+
+	var recs []tracing.Record
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var rec tracing.Record
+		if err := dec.Decode(&rec); err != nil {
+			t.Fatalf("Should be able to decode the span: %s", err)
+		}
+		recs = append(recs, rec)
+	}
+
+	if len(recs) != 2 {
+		t.Fatalf("Should export 2 spans, got %d", len(recs))
+	}
+
+	for _, rec := range recs {
+		if rec.TraceID != remote.TraceID.String() {
+			t.Errorf("Should continue the remote trace, got %s", rec.TraceID)
+		}
+	}
+	if recs[0].ParentID != recs[1].SpanID {
+		t.Errorf("Should parent the child span to the request span")
+	}
+	if recs[1].ParentID != remote.SpanID.String() {
+		t.Errorf("Should parent the request span to the remote span, got %s", recs[1].ParentID)
+	}
+
+	// A trace the caller didn't sample isn't exported.
+	buf.Reset()
+	remote.Flags = 0
+	_, span = tracer.StartRemote(context.Background(), remote, "request")
+	span.End()
+
+	if buf.Len() != 0 {
+		t.Errorf("Should not export a span that isn't sampled, got %s", buf.String())
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // ctxKey represents the type of value for the context key.
@@ -19,6 +21,16 @@ type Values struct {
 	StatusCode int
 }
 
+// NewTraceContext returns a context with a newly generated trace id for work
+// that doesn't start with a request, like startup or background processing.
+func NewTraceContext(ctx context.Context) context.Context {
+	v := Values{
+		TraceID: uuid.New().String(),
+		Now:     time.Now().UTC(),
+	}
+	return context.WithValue(ctx, key, &v)
+}
+
 // GetValues returns the values from the context.
 func GetValues(ctx context.Context) (*Values, error) {
 	v, ok := ctx.Value(key).(*Values)
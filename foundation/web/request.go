@@ -1,22 +1,44 @@
 package web
 
 import (
+	"context"
 	"encoding/json"
+	"net"
 	"net/http"
 
 	"github.com/dimfeld/httptreemux/v5"
 )
 
+// validatorKey is how the validator for the app is stored in the request
+// context.
+const validatorKey ctxKey = 2
+
 // Param returns the web call parameters from the request.
 func Param(r *http.Request, key string) string {
 	m := httptreemux.ContextParams(r.Context())
 	return m[key]
 }
 
+// GetRoute returns the route pattern that matched the request, like
+// /v1/tx/status/:account/:nonce.
+func GetRoute(ctx context.Context) string {
+	return httptreemux.ContextRoute(ctx)
+}
+
+// RemoteIP returns the IP of the client without the port.
+func RemoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 // Decode reads the body of an HTTP request looking for a JSON document. The
 // body is decoded into the provided value.
 //
-// If the provided value is a struct then it is checked for validation tags.
+// If the app was constructed with a validator, the decoded value is checked
+// with it.
 func Decode(r *http.Request, val any) error {
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
@@ -24,5 +46,11 @@ func Decode(r *http.Request, val any) error {
 		return err
 	}
 
+	if validator, ok := r.Context().Value(validatorKey).(func(val any) error); ok {
+		if err := validator(val); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
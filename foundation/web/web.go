@@ -8,6 +8,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/tracing"
 	"github.com/dimfeld/httptreemux/v5"
 	"github.com/google/uuid"
 )
@@ -16,20 +17,32 @@ import (
 // framework.
 type Handler func(ctx context.Context, w http.ResponseWriter, r *http.Request) error
 
+// Route represents the method and full path of a registered route.
+type Route struct {
+	Method string
+	Path   string
+}
+
 // App is the entrypoint into our application and what configures our context
 // object for each of our http handlers. Feel free to add any configuration
 // data/logic on this App struct.
 type App struct {
 	*httptreemux.ContextMux
-	shutdown chan os.Signal
-	mw       []Middleware
+	shutdown  chan os.Signal
+	tracer    *tracing.Tracer
+	validator func(val any) error
+	mw        []Middleware
+	routes    []Route
 }
 
 // NewApp creates an App value that handle a set of routes for the application.
-func NewApp(shutdown chan os.Signal, mw ...Middleware) *App {
+// The tracer starts a span for each request and can be nil to not record
+// any spans. The validator is used by Decode to check the decoded value and
+// can be nil to not check anything.
+func NewApp(shutdown chan os.Signal, tracer *tracing.Tracer, validator func(val any) error, mw ...Middleware) *App {
 
-	// Create an OpenTelemetry HTTP Handler which wraps our router. This will start
-	// the initial span and annotate it with information about the request/response.
+	// Each request starts the initial span and annotates it with information
+	// about the request/response.
 	//
 	// This is configured to use the W3C TraceContext standard to set the remote
 	// parent if a client request includes the appropriate headers.
@@ -38,6 +51,8 @@ func NewApp(shutdown chan os.Signal, mw ...Middleware) *App {
 	return &App{
 		ContextMux: httptreemux.NewContextMux(),
 		shutdown:   shutdown,
+		tracer:     tracer,
+		validator:  validator,
 		mw:         mw,
 	}
 }
@@ -58,6 +73,11 @@ func (a *App) Handle(method string, group string, path string, handler Handler,
 	// Add the application's general middleware to the handler chain.
 	handler = wrapMiddleware(a.mw, handler)
 
+	finalPath := path
+	if group != "" {
+		finalPath = "/" + group + path
+	}
+
 	// The function to execute for each request.
 	h := func(w http.ResponseWriter, r *http.Request) {
 
@@ -65,24 +85,66 @@ func (a *App) Handle(method string, group string, path string, handler Handler,
 		// use it as a separate parameter.
 		ctx := r.Context()
 
+		// Continue the trace of the caller when the request carries a
+		// traceparent header, otherwise this request starts a new trace.
+		remote, _ := tracing.Extract(r.Header)
+		ctx, span := a.startSpan(ctx, remote, method+" "+finalPath)
+		defer span.End()
+
+		// The trace id is kept in the uuid format the logs have always used,
+		// but it's the same 16 bytes as the trace id in the traceparent.
+		traceID := uuid.UUID(span.SpanContext().TraceID)
+		if span == nil {
+			traceID = uuid.UUID(remote.TraceID)
+			if remote.TraceID == (tracing.TraceID{}) {
+				traceID = uuid.New()
+			}
+		}
+
 		// Set the context with the required values to
 		// process the request.
 		v := Values{
-			TraceID: uuid.New().String(),
+			TraceID: traceID.String(),
 			Now:     time.Now().UTC(),
 		}
 		ctx = context.WithValue(ctx, key, &v)
 
+		// Decode finds the validator for the app through the request.
+		if a.validator != nil {
+			ctx = context.WithValue(ctx, validatorKey, a.validator)
+			r = r.WithContext(ctx)
+		}
+
 		// Call the wrapped handler functions.
-		if err := handler(ctx, w, r); err != nil {
+		err := handler(ctx, w, r)
+
+		span.SetAttribute("http.method", r.Method)
+		span.SetAttribute("http.route", finalPath)
+		span.SetAttribute("http.status_code", v.StatusCode)
+
+		if err != nil {
+			span.SetError(err)
 			a.SignalShutdown()
 			return
 		}
 	}
 
-	finalPath := path
-	if group != "" {
-		finalPath = "/" + group + path
-	}
 	a.ContextMux.Handle(method, finalPath, h)
+	a.routes = append(a.routes, Route{Method: method, Path: finalPath})
+}
+
+// startSpan starts the span for the request when the app has a tracer.
+func (a *App) startSpan(ctx context.Context, remote tracing.SpanContext, name string) (context.Context, *tracing.Span) {
+	if a.tracer == nil {
+		return ctx, nil
+	}
+	return a.tracer.StartRemote(ctx, remote, name)
+}
+
+// Routes returns the set of routes registered with the app in the order they
+// were registered.
+func (a *App) Routes() []Route {
+	routes := make([]Route, len(a.routes))
+	copy(routes, a.routes)
+	return routes
 }
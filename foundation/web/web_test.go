@@ -0,0 +1,94 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/tracing"
+	"github.com/Kunmeer-SyedMohamedHyder/blockchain/foundation/web"
+)
+
+func TestTraceID(t *testing.T) {
+	const (
+		traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+		traceID     = "4bf92f35-77b3-4da6-a3ce-929d0e0e4736"
+	)
+
+	var buf bytes.Buffer
+	tracer := tracing.NewTracer("node", tracing.NewWriterExporter(&buf), nil)
+
+	tt := []struct {
+		name   string
+		tracer *tracing.Tracer
+		header string
+		exp    string
+	}{
+		{name: "continues the remote trace", tracer: tracer, header: traceParent, exp: traceID},
+		{name: "no tracer keeps the remote trace id", header: traceParent, exp: traceID},
+		{name: "bad header starts a new trace", tracer: tracer, header: "00-zz-00f067aa0ba902b7-01"},
+		{name: "no header starts a new trace", tracer: tracer},
+		{name: "no header and no tracer"},
+	}
+
+	for _, tst := range tt {
+		t.Run(tst.name, func(t *testing.T) {
+			var got string
+			h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+				got = web.GetTraceID(ctx)
+				return web.Respond(ctx, w, nil, http.StatusNoContent)
+			}
+
+			app := web.NewApp(nil, tst.tracer, nil)
+			app.Handle(http.MethodGet, "v1", "/test", h)
+
+			r := httptest.NewRequest(http.MethodGet, "/v1/test", nil)
+			if tst.header != "" {
+				r.Header.Set(tracing.HeaderTraceParent, tst.header)
+			}
+			app.ServeHTTP(httptest.NewRecorder(), r)
+
+			switch {
+			case tst.exp != "" && got != tst.exp:
+				t.Errorf("Should get the trace id %s, got %s", tst.exp, got)
+			case tst.exp == "" && (got == "" || got == traceID || got == "00000000-0000-0000-0000-000000000000"):
+				t.Errorf("Should get a new trace id, got %q", got)
+			}
+		})
+	}
+}
+
+func TestSpan(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := tracing.NewTracer("node", tracing.NewWriterExporter(&buf), nil)
+
+	h := func(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+		return web.Respond(ctx, w, nil, http.StatusNoContent)
+	}
+
+	app := web.NewApp(nil, tracer, nil)
+	app.Handle(http.MethodGet, "v1", "/accounts/:account", h)
+
+	r := httptest.NewRequest(http.MethodGet, "/v1/accounts/bill", nil)
+	r.Header.Set(tracing.HeaderTraceParent, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	app.ServeHTTP(httptest.NewRecorder(), r)
+
+	var rec tracing.Record
+	if err := json.NewDecoder(strings.NewReader(buf.String())).Decode(&rec); err != nil {
+		t.Fatalf("Should export the span for the request: %s", err)
+	}
+
+	if rec.Name != "GET /v1/accounts/:account" {
+		t.Errorf("Should name the span after the route, got %s", rec.Name)
+	}
+	if rec.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || rec.ParentID != "00f067aa0ba902b7" {
+		t.Errorf("Should continue the caller's trace, got trace %s parent %s", rec.TraceID, rec.ParentID)
+	}
+	if rec.Attributes["http.route"] != "/v1/accounts/:account" || rec.Attributes["http.status_code"] != float64(http.StatusNoContent) {
+		t.Errorf("Should record the route and status code, got %v", rec.Attributes)
+	}
+}
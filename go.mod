@@ -1,6 +1,6 @@
 module github.com/Kunmeer-SyedMohamedHyder/blockchain
 
-go 1.19
+go 1.20
 
 require (
 	github.com/ardanlabs/conf/v3 v3.1.5
@@ -8,7 +8,10 @@ require (
 	github.com/ethereum/go-ethereum v1.12.0
 	github.com/google/uuid v1.3.0
 	github.com/spf13/cobra v1.7.0
+	github.com/tyler-smith/go-bip39 v1.1.0
 	go.uber.org/zap v1.24.0
+	golang.org/x/crypto v0.9.0
+	golang.org/x/term v0.8.0
 )
 
 require (
@@ -19,6 +22,5 @@ require (
 	github.com/spf13/pflag v1.0.5 // indirect
 	go.uber.org/atomic v1.11.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
-	golang.org/x/crypto v0.9.0 // indirect
 	golang.org/x/sys v0.8.0 // indirect
 )